@@ -2,13 +2,12 @@ package main
 
 import (
 	"fmt"
-	"math"
 	"math/rand"
 	"os"
 	"sort"
-	"strconv"
 	"time"
 
+	"github.com/m0t0k1ch1/nebula/generate"
 	"github.com/m0t0k1ch1/nebula/graph"
 	"github.com/m0t0k1ch1/nebula/utils"
 )
@@ -20,39 +19,10 @@ const (
 	filePath = "./ws.dot"
 )
 
-func newID(id int) graph.ID {
-	return graph.ID(strconv.Itoa(id))
-}
-
-func pickRandomID(excludes map[graph.ID]bool) (id graph.ID) {
-	ok := false
-	for !ok {
-		id = newID(rand.Intn(n))
-		if _, ok := excludes[id]; ok {
-			continue
-		}
-		ok = true
-	}
-	return
-}
-
-func pickRandomIDFromEnds(ends map[graph.ID]*graph.Node) (id graph.ID) {
-	i, target := 0, rand.Intn(len(ends))
-	for id, _ = range ends {
-		if i == target {
-			break
-		}
-		i++
-	}
-	return
-}
-
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
-
 func main() {
-	g, err := createGraph()
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	g, err := generate.WattsStrogatz(n, kAvg, p, rng)
 	if err != nil {
 		panic(err)
 	}
@@ -64,88 +34,6 @@ func main() {
 	}
 }
 
-func createGraph() (*graph.Graph, error) {
-	g := graph.NewUndirected()
-
-	// add nodes
-	for i := 0; i < n; i++ {
-		g.AddNode(graph.NewNode(strconv.Itoa(i)))
-	}
-
-	// add edges
-	for i := 0; i < n; i++ {
-		for j := i + 1; j <= i+kAvg/2; j++ {
-			idTail, idHead := i, j
-			if idHead >= n {
-				idHead -= n
-			}
-
-			if err := g.AddEdge(newID(idTail), newID(idHead), 1.0); err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	targetsNum := int(math.Floor(float64(n) * float64(kAvg/2) * p))
-	targets := map[graph.ID]map[graph.ID]bool{}
-	cnt := 0
-
-	// pick target edges
-	for cnt < targetsNum {
-		idTail := pickRandomID(nil)
-
-		heads, err := g.GetHeads(idTail)
-		if err != nil {
-			return nil, err
-		}
-		idHead := pickRandomIDFromEnds(heads)
-
-		if _, ok := targets[idTail]; ok {
-			if _, ok := targets[idTail][idHead]; ok {
-				continue
-			}
-		}
-		if _, ok := targets[idHead]; ok {
-			if _, ok := targets[idHead][idTail]; ok {
-				continue
-			}
-		}
-
-		if _, ok := targets[idTail]; ok {
-			targets[idTail][idHead] = true
-		} else {
-			targets[idTail] = map[graph.ID]bool{idHead: true}
-		}
-
-		cnt++
-	}
-
-	// switch edges
-	for idTail, targetHeads := range targets {
-		for idHeadOld, _ := range targetHeads {
-			heads, err := g.GetHeads(idTail)
-			if err != nil {
-				return nil, err
-			}
-			excludes := map[graph.ID]bool{idTail: true}
-			for _, node := range heads {
-				excludes[node.ID()] = true
-			}
-
-			if err := g.RemoveEdge(idTail, idHeadOld); err != nil {
-				return nil, err
-			}
-
-			idHeadNew := pickRandomID(excludes)
-			if err := g.AddEdge(idTail, idHeadNew, 1.0); err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	return g, nil
-}
-
 func writeGraphFeatures(g *graph.Graph) {
 	kDist := g.GetIndegreeDistribution()
 	sort.Sort(kDist)
@@ -0,0 +1,201 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrActivityInvalidN        = errors.New("generate: n must be 0 or more")
+	ErrActivityInvalidM        = errors.New("generate: m must be between 1 and n-1")
+	ErrActivityInvalidSteps    = errors.New("generate: the number of the steps must be 0 or more")
+	ErrActivityInvalidActivity = errors.New("generate: the activity must be between 0 and 1")
+	ErrActivityInvalidMemory   = errors.New("generate: the memory parameter must be more than 0")
+	ErrActivityInvalidWindow   = errors.New("generate: the end of the time window must not be before the start")
+)
+
+// Contact is a contact that Tail initiates with Head at Time.
+type Contact struct {
+	Time int
+	Tail graph.ID
+	Head graph.ID
+}
+
+// ActivityDriven generates the contacts of the activity-driven temporal network of n nodes
+// over the time steps 0 to steps-1, ordered by time.
+// The activity of each node is sampled by activity, and at each step each node is active
+// with probability of its activity, initiating contacts with m distinct other nodes chosen uniformly.
+func ActivityDriven(n, m, steps int, activity func(*rand.Rand) float64, rng *rand.Rand) ([]Contact, error) {
+	return activityDriven(n, m, steps, activity, 0, rng)
+}
+
+// ActivityDrivenWithMemory generates the contacts of the activity-driven temporal network
+// with the memory of Karsai et al., in which an active node that has contacted k distinct nodes
+// contacts a new node with probability c / (c + k),
+// or otherwise reinforces the contact with one of the k nodes chosen uniformly.
+func ActivityDrivenWithMemory(n, m, steps int, activity func(*rand.Rand) float64, c float64, rng *rand.Rand) ([]Contact, error) {
+	if c <= 0 || math.IsNaN(c) || math.IsInf(c, 0) {
+		return nil, ErrActivityInvalidMemory
+	}
+	return activityDriven(n, m, steps, activity, c, rng)
+}
+
+// activityDriven generates the contacts without the memory if c is 0.
+func activityDriven(n, m, steps int, activity func(*rand.Rand) float64, c float64, rng *rand.Rand) ([]Contact, error) {
+	if n < 0 {
+		return nil, ErrActivityInvalidN
+	}
+	if m < 1 || (n > 0 && m >= n) {
+		return nil, ErrActivityInvalidM
+	}
+	if steps < 0 {
+		return nil, ErrActivityInvalidSteps
+	}
+
+	activities := make([]float64, n)
+	for v := range activities {
+		a := activity(rng)
+		if !isValidProbability(a) {
+			return nil, ErrActivityInvalidActivity
+		}
+		activities[v] = a
+	}
+
+	// the nodes that each node has contacted in either direction, in order of the first contact
+	contacted := make([][]int, n)
+	isContacted := make([]map[int]bool, n)
+	for v := range isContacted {
+		isContacted[v] = map[int]bool{}
+	}
+	remember := func(v, w int) {
+		if !isContacted[v][w] {
+			isContacted[v][w] = true
+			contacted[v] = append(contacted[v], w)
+		}
+	}
+
+	contacts := []Contact{}
+	for t := 0; t < steps; t++ {
+		pairs := [][2]int{}
+		for v := 0; v < n; v++ {
+			if rng.Float64() >= activities[v] {
+				continue
+			}
+
+			var ws []int
+			if c > 0 {
+				ws = pickWithMemory(n, m, v, c, contacted[v], isContacted[v], rng)
+			} else {
+				ws = pickUniformOthers(n, m, v, rng)
+			}
+
+			for _, w := range ws {
+				contacts = append(contacts, Contact{t, newID(v), newID(w)})
+				pairs = append(pairs, [2]int{v, w})
+			}
+		}
+
+		// the contacts are remembered after the step
+		if c > 0 {
+			for _, pair := range pairs {
+				remember(pair[0], pair[1])
+				remember(pair[1], pair[0])
+			}
+		}
+	}
+
+	return contacts, nil
+}
+
+// pickUniformOthers samples m distinct nodes other than v from n nodes by Floyd's algorithm.
+func pickUniformOthers(n, m, v int, rng *rand.Rand) []int {
+	ws := make([]int, 0, m)
+	picked := make(map[int]bool, m)
+	for j := n - 1 - m; j < n-1; j++ {
+		x := rng.Intn(j + 1)
+		if picked[x] {
+			x = j
+		}
+		picked[x] = true
+
+		w := x
+		if w >= v {
+			w++
+		}
+		ws = append(ws, w)
+	}
+	return ws
+}
+
+// pickWithMemory samples m distinct nodes other than v,
+// each of which is a new node with probability c / (c + k) or otherwise one of the k contacted nodes.
+// It falls back to the other kind when either kind runs out.
+func pickWithMemory(n, m, v int, c float64, contacted []int, isContacted map[int]bool, rng *rand.Rand) []int {
+	k := len(contacted)
+	ws := make([]int, 0, m)
+	picked := make(map[int]bool, m)
+	newsNum, oldsNum := 0, 0
+
+	for len(ws) < m {
+		hasNew := newsNum < n-1-k
+		hasOld := oldsNum < k
+
+		var w int
+		if hasNew && (!hasOld || rng.Float64() < c/(c+float64(k))) {
+			w = rng.Intn(n)
+			for w == v || isContacted[w] || picked[w] {
+				w = rng.Intn(n)
+			}
+			newsNum++
+		} else {
+			w = contacted[rng.Intn(k)]
+			for picked[w] {
+				w = contacted[rng.Intn(k)]
+			}
+			oldsNum++
+		}
+
+		picked[w] = true
+		ws = append(ws, w)
+	}
+
+	return ws
+}
+
+// Aggregate generates a graph of the contacts from time from to to-1,
+// in which the weight of each edge is the number of the contacts between its ends,
+// accumulated by adding the edge once for each of them.
+// The contacts must be ordered by time, and the graph has only the nodes in the contacts in the window.
+func Aggregate(contacts []Contact, from, to int, isDirected bool) (*graph.Graph, error) {
+	if to < from {
+		return nil, ErrActivityInvalidWindow
+	}
+
+	g := newGraph(isDirected)
+
+	start := sort.Search(len(contacts), func(i int) bool {
+		return contacts[i].Time >= from
+	})
+	for _, contact := range contacts[start:] {
+		if contact.Time >= to {
+			break
+		}
+		for _, id := range []graph.ID{contact.Tail, contact.Head} {
+			if _, err := g.GetNode(id); err == nil {
+				continue
+			}
+			if err := g.AddNode(graph.NewNode(id.String())); err != nil {
+				return nil, err
+			}
+		}
+		if err := g.AddEdge(contact.Tail, contact.Head, 1.0); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
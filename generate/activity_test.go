@@ -0,0 +1,214 @@
+package generate
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func constantActivity(a float64) func(*rand.Rand) float64 {
+	return func(*rand.Rand) float64 {
+		return a
+	}
+}
+
+func testContacts(t *testing.T, contacts []Contact) {
+	t.Helper()
+
+	picked := map[Contact]bool{}
+	for i, contact := range contacts {
+		if i > 0 && contact.Time < contacts[i-1].Time {
+			t.Errorf("expected: %d or more, actual: %d", contacts[i-1].Time, contact.Time)
+		}
+		if contact.Tail == contact.Head {
+			t.Errorf("expected: different nodes, actual: %s", contact.Tail)
+		}
+		if picked[contact] {
+			t.Errorf("expected: distinct contacts, actual: %v", contact)
+		}
+		picked[contact] = true
+	}
+}
+
+func TestActivityDriven(t *testing.T) {
+	type input struct {
+		n, m, steps int
+		activity    float64
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{"success", input{100, 2, 50, 0.3}, output{nil}},
+		{"success: always active", input{20, 3, 10, 1}, output{nil}},
+		{"success: never active", input{20, 3, 10, 0}, output{nil}},
+		{"success: complete", input{5, 4, 10, 1}, output{nil}},
+		{"failure: invalid n", input{-1, 2, 10, 0.3}, output{ErrActivityInvalidN}},
+		{"failure: invalid m", input{5, 5, 10, 0.3}, output{ErrActivityInvalidM}},
+		{"failure: zero m", input{5, 0, 10, 0.3}, output{ErrActivityInvalidM}},
+		{"failure: invalid steps", input{5, 2, -1, 0.3}, output{ErrActivityInvalidSteps}},
+		{"failure: invalid activity", input{5, 2, 10, 1.5}, output{ErrActivityInvalidActivity}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			contacts, err := ActivityDriven(in.n, in.m, in.steps, constantActivity(in.activity), newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testContacts(t, contacts)
+
+			expected := in.activity * float64(in.n*in.m*in.steps)
+			if math.Abs(float64(len(contacts))-expected) > 0.1*expected {
+				t.Errorf("expected: about %v, actual: %d", expected, len(contacts))
+			}
+		})
+	}
+}
+
+func TestActivityDrivenWithMemory(t *testing.T) {
+	type input struct {
+		n, m, steps int
+		c           float64
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{"success", input{100, 2, 50, 1}, output{nil}},
+		{"success: complete", input{5, 4, 10, 1}, output{nil}},
+		{"failure: invalid c", input{100, 2, 50, 0}, output{ErrActivityInvalidMemory}},
+		{"failure: invalid m", input{5, 5, 10, 1}, output{ErrActivityInvalidM}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			contacts, err := ActivityDrivenWithMemory(in.n, in.m, in.steps, constantActivity(0.5), in.c, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testContacts(t, contacts)
+		})
+	}
+
+	// the memory reinforces the past contacts, reducing the distinct pairs
+	memoryless, err := ActivityDriven(100, 2, 50, constantActivity(0.5), newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	memorized, err := ActivityDrivenWithMemory(100, 2, 50, constantActivity(0.5), 0.5, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	g1, err := Aggregate(memoryless, 0, 50, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2, err := Aggregate(memorized, 0, 50, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edgesNum1, edgesNum2 := countEdges(g1), countEdges(g2); edgesNum2*2 > edgesNum1 {
+		t.Errorf("expected: less than %d, actual: %d", edgesNum1/2, edgesNum2)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	contacts := []Contact{
+		{0, "0", "1"},
+		{1, "1", "0"},
+		{1, "2", "3"},
+		{2, "0", "1"},
+		{2, "1", "2"},
+		{4, "3", "4"},
+	}
+
+	type input struct {
+		from, to   int
+		isDirected bool
+	}
+	type output struct {
+		nodesNum int
+		weights  map[[2]graph.ID]float64
+		err      error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{
+			"success: all",
+			input{0, 5, false},
+			output{5, map[[2]graph.ID]float64{{"0", "1"}: 3, {"2", "3"}: 1, {"1", "2"}: 1, {"3", "4"}: 1}, nil},
+		},
+		{
+			"success: window",
+			input{1, 3, false},
+			output{4, map[[2]graph.ID]float64{{"0", "1"}: 2, {"2", "3"}: 1, {"1", "2"}: 1}, nil},
+		},
+		{
+			"success: directed",
+			input{0, 3, true},
+			output{4, map[[2]graph.ID]float64{{"0", "1"}: 2, {"1", "0"}: 1, {"2", "3"}: 1, {"1", "2"}: 1}, nil},
+		},
+		{
+			"success: empty window",
+			input{3, 3, false},
+			output{0, map[[2]graph.ID]float64{}, nil},
+		},
+		{
+			"failure: invalid window",
+			input{3, 2, false},
+			output{0, nil, ErrActivityInvalidWindow},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := Aggregate(contacts, in.from, in.to, in.isDirected)
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testNodesNum(t, out.nodesNum, g)
+			testEdgesNum(t, len(out.weights), g)
+			for pair, weight := range out.weights {
+				edge, err := g.GetEdge(pair[0], pair[1])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if edge.Weight() != weight {
+					t.Errorf("expected: %v, actual: %v", weight, edge.Weight())
+				}
+			}
+		})
+	}
+}
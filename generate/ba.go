@@ -0,0 +1,92 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrBAInvalidM0 = errors.New("generate: m0 must be 1 or more")
+	ErrBAInvalidM  = errors.New("generate: m must be 1 or more and m0 or less")
+	ErrBAInvalidN  = errors.New("generate: n must be m0 or more")
+)
+
+// BarabasiAlbert generates an undirected graph of n nodes by the BA model,
+// growing from a ring of m0 nodes and attaching each new node to m nodes.
+func BarabasiAlbert(n, m0, m int, rng *rand.Rand) (*graph.Graph, error) {
+	if m0 < 1 {
+		return nil, ErrBAInvalidM0
+	}
+	if m < 1 || m > m0 {
+		return nil, ErrBAInvalidM
+	}
+	if n < m0 {
+		return nil, ErrBAInvalidN
+	}
+
+	g := graph.NewUndirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	// every edge appends both of its ends,
+	// so picking uniformly from ends is picking proportionally to degrees
+	ends := make([]int, 0, 2*(m0+(n-m0)*m))
+
+	// add default edges
+	for _, pair := range ringPairs(m0) {
+		if err := g.AddEdge(newID(pair[0]), newID(pair[1]), 1.0); err != nil {
+			return nil, err
+		}
+		ends = append(ends, pair[0], pair[1])
+	}
+
+	picked := make(map[int]bool, m)
+	targets := make([]int, 0, m)
+
+	for i := m0; i < n; i++ {
+		// pick target nodes
+		for k := range picked {
+			delete(picked, k)
+		}
+		targets = targets[:0]
+		for len(targets) < m {
+			var j int
+			if len(ends) == 0 {
+				j = rng.Intn(i)
+			} else {
+				j = ends[rng.Intn(len(ends))]
+			}
+			if picked[j] {
+				continue
+			}
+			picked[j] = true
+			targets = append(targets, j)
+		}
+
+		// add edges
+		for _, j := range targets {
+			if err := g.AddEdge(newID(i), newID(j), 1.0); err != nil {
+				return nil, err
+			}
+			ends = append(ends, i, j)
+		}
+	}
+
+	return g, nil
+}
+
+// ringPairs lists the pairs of the adjacent nodes in a ring of nodes 0 to n-1,
+// which degenerates into a path if n is 2 or less.
+func ringPairs(n int) [][2]int {
+	pairs := [][2]int{}
+	for i := 0; i < n-1; i++ {
+		pairs = append(pairs, [2]int{i, i + 1})
+	}
+	if n >= 3 {
+		pairs = append(pairs, [2]int{n - 1, 0})
+	}
+	return pairs
+}
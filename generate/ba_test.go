@@ -0,0 +1,103 @@
+package generate
+
+import "testing"
+
+func TestBarabasiAlbert(t *testing.T) {
+	type input struct {
+		n, m0, m int
+	}
+	type output struct {
+		edgesNum int
+		err      error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{
+			"success",
+			input{100, 3, 2},
+			output{3 + 97*2, nil},
+		},
+		{
+			"success: m0 = 2",
+			input{50, 2, 2},
+			output{1 + 48*2, nil},
+		},
+		{
+			"success: m0 = 1",
+			input{50, 1, 1},
+			output{49, nil},
+		},
+		{
+			"success: n = m0",
+			input{5, 5, 3},
+			output{5, nil},
+		},
+		{
+			"failure: invalid m0",
+			input{10, 0, 1},
+			output{0, ErrBAInvalidM0},
+		},
+		{
+			"failure: m greater than m0",
+			input{10, 2, 3},
+			output{0, ErrBAInvalidM},
+		},
+		{
+			"failure: invalid m",
+			input{10, 2, 0},
+			output{0, ErrBAInvalidM},
+		},
+		{
+			"failure: n less than m0",
+			input{2, 3, 2},
+			output{0, ErrBAInvalidN},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := BarabasiAlbert(in.n, in.m0, in.m, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if g.IsDirected() {
+				t.Errorf("expected: %t, actual: %t", false, g.IsDirected())
+			}
+			testNodesNum(t, in.n, g)
+			testEdgesNum(t, out.edgesNum, g)
+			testUnitWeights(t, g)
+			for i := in.m0; i < in.n; i++ {
+				if k := degree(t, g, newID(i)); k < in.m {
+					t.Errorf("expected: >= %d, actual: %d", in.m, k)
+				}
+			}
+		})
+	}
+}
+
+func TestBarabasiAlbert_Reproducible(t *testing.T) {
+	g1, err := BarabasiAlbert(200, 3, 2, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2, err := BarabasiAlbert(200, 3, 2, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idTail, nodeEdges := range g1.GetEdges() {
+		for idHead := range nodeEdges {
+			if _, err := g2.GetEdge(idTail, idHead); err != nil {
+				t.Errorf("expected: %v, actual: %v", nil, err)
+			}
+		}
+	}
+}
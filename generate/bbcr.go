@@ -0,0 +1,92 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrBBCRInvalidN             = errors.New("generate: n must be 3 or more")
+	ErrBBCRInvalidProbabilities = errors.New("generate: alpha, beta and gamma must be 0 or more and sum up to 1, and alpha + gamma must be more than 0")
+	ErrBBCRInvalidDeltas        = errors.New("generate: the deltas must be 0 or more")
+)
+
+// DirectedPreferentialAttachment generates a directed graph of n nodes by the
+// Bollobás–Borgs–Chayes–Riordan model, growing from a directed cycle of 3 nodes.
+// At each step, with probability alpha a new node links to an existing node w,
+// with probability beta an existing node v links to an existing node w, and
+// with probability gamma an existing node v links to a new node,
+// where v is chosen with probability proportional to its out-degree plus deltaOut
+// and w to its in-degree plus deltaIn. The steps creating loops or multi-edges are skipped.
+func DirectedPreferentialAttachment(n int, alpha, beta, gamma, deltaIn, deltaOut float64, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 3 {
+		return nil, ErrBBCRInvalidN
+	}
+	if alpha < 0 || beta < 0 || gamma < 0 || alpha+gamma <= 0 || math.Abs(alpha+beta+gamma-1) > 1e-9 {
+		return nil, ErrBBCRInvalidProbabilities
+	}
+	if deltaIn < 0 || deltaOut < 0 {
+		return nil, ErrBBCRInvalidDeltas
+	}
+
+	g := graph.NewDirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	inSampler := newAttachmentSampler(deltaIn)
+	outSampler := newAttachmentSampler(deltaOut)
+
+	addEdge := func(v, w int) error {
+		if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+			return err
+		}
+		outSampler.add(v)
+		inSampler.add(w)
+		return nil
+	}
+
+	// add default edges
+	for v := 0; v < 3; v++ {
+		if err := addEdge(v, (v+1)%3); err != nil {
+			return nil, err
+		}
+	}
+
+	for nodesNum := 3; nodesNum < n; {
+		x := rng.Float64()
+		switch {
+		case x < alpha:
+			w := inSampler.sample(nodesNum, rng)
+			if err := addEdge(nodesNum, w); err != nil {
+				return nil, err
+			}
+			nodesNum++
+		case x < alpha+beta:
+			v := outSampler.sample(nodesNum, rng)
+			w := inSampler.sample(nodesNum, rng)
+			if v == w {
+				continue
+			}
+			if ok, err := isAdjacent(g, newID(v), newID(w)); err != nil {
+				return nil, err
+			} else if ok {
+				continue
+			}
+			if err := addEdge(v, w); err != nil {
+				return nil, err
+			}
+		default:
+			v := outSampler.sample(nodesNum, rng)
+			if err := addEdge(v, nodesNum); err != nil {
+				return nil, err
+			}
+			nodesNum++
+		}
+	}
+
+	return g, nil
+}
@@ -0,0 +1,48 @@
+package generate
+
+import "testing"
+
+func TestDirectedPreferentialAttachment(t *testing.T) {
+	g, err := DirectedPreferentialAttachment(2000, 0.41, 0.54, 0.05, 0.2, 0, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.IsDirected() {
+		t.Errorf("expected: %t, actual: %t", true, g.IsDirected())
+	}
+	testNodesNum(t, 2000, g)
+	testUnitWeights(t, g)
+	if countEdges(g) < 2000 {
+		t.Errorf("expected: >= %d, actual: %d", 2000, countEdges(g))
+	}
+
+	t.Run("success: tuned tails", func(t *testing.T) {
+		// a large deltaOut flattens the out-degree tail only
+		g, err := DirectedPreferentialAttachment(2000, 0.41, 0.54, 0.05, 0, 100, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		kInMax, kOutMax := maxDegree(t, g, true), maxDegree(t, g, false)
+		if kInMax <= 2*kOutMax {
+			t.Errorf("expected: > %d, actual: %d", 2*kOutMax, kInMax)
+		}
+	})
+
+	t.Run("failure: invalid n", func(t *testing.T) {
+		if _, err := DirectedPreferentialAttachment(2, 0.41, 0.54, 0.05, 0.2, 0, newTestRand()); err != ErrBBCRInvalidN {
+			t.Errorf("expected: %v, actual: %v", ErrBBCRInvalidN, err)
+		}
+	})
+
+	t.Run("failure: invalid probabilities", func(t *testing.T) {
+		if _, err := DirectedPreferentialAttachment(10, 0, 1, 0, 0.2, 0, newTestRand()); err != ErrBBCRInvalidProbabilities {
+			t.Errorf("expected: %v, actual: %v", ErrBBCRInvalidProbabilities, err)
+		}
+	})
+
+	t.Run("failure: invalid deltas", func(t *testing.T) {
+		if _, err := DirectedPreferentialAttachment(10, 0.41, 0.54, 0.05, -1, 0, newTestRand()); err != ErrBBCRInvalidDeltas {
+			t.Errorf("expected: %v, actual: %v", ErrBBCRInvalidDeltas, err)
+		}
+	})
+}
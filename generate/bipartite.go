@@ -0,0 +1,242 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrBipartiteInvalidN          = errors.New("generate: the numbers of the nodes must be 0 or more")
+	ErrBipartiteInvalidM          = errors.New("generate: m must be 1 or more")
+	ErrBipartiteDegreeSumMismatch = errors.New("generate: the sums of the degrees of the two sides must be the same")
+	ErrBipartiteInvalidSide       = errors.New("generate: the side must be 0 or 1")
+	ErrNotBipartite               = errors.New("generate: the graph must be undirected and have edges only between the two sides")
+)
+
+// the sides of the nodes of bipartite graphs
+const (
+	SideTop    = 0
+	SideBottom = 1
+)
+
+// BipartiteGNP generates an undirected bipartite graph of the top nodes 0 to n1-1
+// and the bottom nodes n1 to n1+n2-1, in which each pair of a top node and a bottom node
+// is connected independently with probability p. It also returns the side of each node.
+func BipartiteGNP(n1, n2 int, p float64, rng *rand.Rand) (*graph.Graph, map[graph.ID]int, error) {
+	if n1 < 0 || n2 < 0 {
+		return nil, nil, ErrBipartiteInvalidN
+	}
+	if !isValidProbability(p) {
+		return nil, nil, ErrInvalidProbability
+	}
+
+	g, sides, err := newBipartiteGraph(n1, n2)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := sampleIndices(int64(n1)*int64(n2), p, rng, func(t int64) error {
+		v, w := int(t/int64(n2)), n1+int(t%int64(n2))
+		return g.AddEdge(newID(v), newID(w), 1.0)
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return g, sides, nil
+}
+
+// BipartiteConfiguration generates an undirected bipartite graph by the configuration model,
+// in which top node i has degrees1[i] stubs and bottom node n1+j has degrees2[j] stubs,
+// where n1 is the length of degrees1, pairing the stubs of the two sides at random
+// and discarding the pairs that would form multi-edges.
+// It also returns the side of each node and the number of the discarded stubs.
+func BipartiteConfiguration(degrees1, degrees2 []int, rng *rand.Rand) (*graph.Graph, map[graph.ID]int, int, error) {
+	sums := [2]int{}
+	for side, degrees := range [][]int{degrees1, degrees2} {
+		for _, k := range degrees {
+			if k < 0 {
+				return nil, nil, 0, ErrInvalidDegree
+			}
+			sums[side] += k
+		}
+	}
+	if sums[0] != sums[1] {
+		return nil, nil, 0, ErrBipartiteDegreeSumMismatch
+	}
+
+	n1 := len(degrees1)
+	g, sides, err := newBipartiteGraph(n1, len(degrees2))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	discarded := 0
+
+	// shuffling the stubs of one side is enough to pair them at random
+	stubs1 := newShuffledStubs(degrees1, rng)
+	stubs2 := []int{}
+	for i, k := range degrees2 {
+		for j := 0; j < k; j++ {
+			stubs2 = append(stubs2, n1+i)
+		}
+	}
+
+	for i := range stubs1 {
+		idTail, idHead := newID(stubs1[i]), newID(stubs2[i])
+		if _, err := g.GetEdge(idTail, idHead); err == nil {
+			discarded += 2
+			continue
+		}
+
+		if err := g.AddEdge(idTail, idHead, 1.0); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	return g, sides, discarded, nil
+}
+
+// BipartitePreferentialAttachment generates an undirected bipartite graph
+// of the top nodes 0 to n1-1 arriving one by one, each linking to m distinct bottom nodes.
+// Each link goes to a new bottom node with probability p, or otherwise to an existing one
+// chosen with probability proportional to its degree.
+// The bottom nodes are numbered from n1 in order of arrival. It also returns the side of each node.
+func BipartitePreferentialAttachment(n1, m int, p float64, rng *rand.Rand) (*graph.Graph, map[graph.ID]int, error) {
+	if n1 < 0 {
+		return nil, nil, ErrBipartiteInvalidN
+	}
+	if m < 1 {
+		return nil, nil, ErrBipartiteInvalidM
+	}
+	if !isValidProbability(p) {
+		return nil, nil, ErrInvalidProbability
+	}
+
+	g, sides, err := newBipartiteGraph(n1, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	n := n1
+	stubs := []int{}
+	for v := 0; v < n1; v++ {
+		picked := map[int]bool{}
+		pickedStubsNum := 0
+
+		for len(picked) < m {
+			var w int
+			if pickedStubsNum == len(stubs) || rng.Float64() < p {
+				// add a new bottom node, also when all the existing ones are picked
+				w = n
+				n++
+				idNew := newID(w)
+				if err := g.AddNode(graph.NewNode(idNew.String())); err != nil {
+					return nil, nil, err
+				}
+				sides[idNew] = SideBottom
+			} else {
+				w = stubs[rng.Intn(len(stubs))]
+				for picked[w] {
+					w = stubs[rng.Intn(len(stubs))]
+				}
+				heads, err := g.GetHeads(newID(w))
+				if err != nil {
+					return nil, nil, err
+				}
+				pickedStubsNum += len(heads)
+			}
+			picked[w] = true
+
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		// register the stubs after the arrival so that the node does not attract itself
+		for w := n1; w < n; w++ {
+			if picked[w] {
+				stubs = append(stubs, w)
+			}
+		}
+	}
+
+	return g, sides, nil
+}
+
+// Projection generates the one-mode projection of the bipartite graph onto the nodes of the side,
+// in which two nodes are connected if they share a neighbor on the other side.
+// If isWeighted, the weight of each edge is the number of the shared neighbors,
+// accumulated by adding the edge once for each of them.
+// The nodes of the projection are copies of the ones of the bipartite graph.
+func Projection(g *graph.Graph, sides map[graph.ID]int, side int, isWeighted bool) (*graph.Graph, error) {
+	if side != SideTop && side != SideBottom {
+		return nil, ErrBipartiteInvalidSide
+	}
+	if g.IsDirected() {
+		return nil, ErrNotBipartite
+	}
+
+	proj := graph.NewUndirected()
+	for id, node := range g.GetNodes() {
+		s, ok := sides[id]
+		if !ok || (s != SideTop && s != SideBottom) {
+			return nil, ErrBipartiteInvalidSide
+		}
+		if s != side {
+			continue
+		}
+		if err := proj.AddNode(node.Copy()); err != nil {
+			return nil, err
+		}
+	}
+
+	for id, heads := range g.GetEdges() {
+		for idHead := range heads {
+			if sides[idHead] == sides[id] {
+				return nil, ErrNotBipartite
+			}
+		}
+		if sides[id] == side {
+			continue
+		}
+
+		ids := make([]graph.ID, 0, len(heads))
+		for idHead := range heads {
+			ids = append(ids, idHead)
+		}
+		for i, idTail := range ids {
+			for _, idHead := range ids[i+1:] {
+				if !isWeighted {
+					if _, err := proj.GetEdge(idTail, idHead); err == nil {
+						continue
+					}
+				}
+				if err := proj.AddEdge(idTail, idHead, 1.0); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return proj, nil
+}
+
+func newBipartiteGraph(n1, n2 int) (*graph.Graph, map[graph.ID]int, error) {
+	g := graph.NewUndirected()
+	if err := addNodes(g, n1+n2); err != nil {
+		return nil, nil, err
+	}
+
+	sides := make(map[graph.ID]int, n1+n2)
+	for v := 0; v < n1+n2; v++ {
+		if v < n1 {
+			sides[newID(v)] = SideTop
+		} else {
+			sides[newID(v)] = SideBottom
+		}
+	}
+
+	return g, sides, nil
+}
@@ -0,0 +1,253 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func testBipartite(t *testing.T, g *graph.Graph, sides map[graph.ID]int) {
+	t.Helper()
+
+	if len(sides) != len(g.GetNodes()) {
+		t.Errorf("expected: %d, actual: %d", len(g.GetNodes()), len(sides))
+	}
+	for id, heads := range g.GetEdges() {
+		for idHead := range heads {
+			if sides[id] == sides[idHead] {
+				t.Errorf("expected: different sides, actual: %s and %s on side %d", id, idHead, sides[id])
+			}
+		}
+	}
+}
+
+func TestBipartiteGNP(t *testing.T) {
+	type input struct {
+		n1, n2 int
+		p      float64
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{"success", input{30, 50, 0.1}, output{nil}},
+		{"success: p = 0", input{30, 50, 0}, output{nil}},
+		{"success: p = 1", input{30, 50, 1}, output{nil}},
+		{"success: empty side", input{30, 0, 0.5}, output{nil}},
+		{"failure: invalid n", input{-1, 50, 0.1}, output{ErrBipartiteInvalidN}},
+		{"failure: invalid p", input{30, 50, 1.1}, output{ErrInvalidProbability}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, sides, err := BipartiteGNP(in.n1, in.n2, in.p, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testNodesNum(t, in.n1+in.n2, g)
+			testUnitWeights(t, g)
+			testBipartite(t, g, sides)
+			if in.p == 0 || in.p == 1 {
+				testEdgesNum(t, int(in.p)*in.n1*in.n2, g)
+			}
+			if sides["0"] != SideTop || (in.n2 > 0 && sides[newID(in.n1)] != SideBottom) {
+				t.Errorf("expected: top and bottom, actual: %d and %d", sides["0"], sides[newID(in.n1)])
+			}
+		})
+	}
+}
+
+func TestBipartiteConfiguration(t *testing.T) {
+	type input struct {
+		degrees1, degrees2 []int
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{"success", input{[]int{3, 2, 2, 1}, []int{2, 2, 2, 2}}, output{nil}},
+		{"success: complete", input{[]int{2, 2, 2}, []int{3, 3}}, output{nil}},
+		{"success: empty", input{[]int{}, []int{}}, output{nil}},
+		{"failure: invalid degree", input{[]int{-1, 1}, []int{0}}, output{ErrInvalidDegree}},
+		{"failure: sum mismatch", input{[]int{2, 1}, []int{2}}, output{ErrBipartiteDegreeSumMismatch}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, sides, discarded, err := BipartiteConfiguration(in.degrees1, in.degrees2, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			n1 := len(in.degrees1)
+			testNodesNum(t, n1+len(in.degrees2), g)
+			testUnitWeights(t, g)
+			testBipartite(t, g, sides)
+
+			// the discarded stubs make up the difference of the degrees
+			sum, actual := 0, 0
+			for _, degrees := range [][]int{in.degrees1, in.degrees2} {
+				for _, k := range degrees {
+					sum += k
+				}
+			}
+			for v := 0; v < len(g.GetNodes()); v++ {
+				actual += degree(t, g, newID(v))
+			}
+			if sum-actual != discarded {
+				t.Errorf("expected: %d, actual: %d", sum-actual, discarded)
+			}
+		})
+	}
+}
+
+func TestBipartitePreferentialAttachment(t *testing.T) {
+	type input struct {
+		n1, m int
+		p     float64
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{"success", input{100, 3, 0.3}, output{nil}},
+		{"success: p = 0", input{100, 3, 0}, output{nil}},
+		{"success: p = 1", input{100, 3, 1}, output{nil}},
+		{"failure: invalid n", input{-1, 3, 0.3}, output{ErrBipartiteInvalidN}},
+		{"failure: invalid m", input{100, 0, 0.3}, output{ErrBipartiteInvalidM}},
+		{"failure: invalid p", input{100, 3, -0.3}, output{ErrInvalidProbability}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, sides, err := BipartitePreferentialAttachment(in.n1, in.m, in.p, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testEdgesNum(t, in.n1*in.m, g)
+			testUnitWeights(t, g)
+			testBipartite(t, g, sides)
+			for v := 0; v < in.n1; v++ {
+				if k := degree(t, g, newID(v)); k != in.m {
+					t.Errorf("expected: %d, actual: %d", in.m, k)
+				}
+			}
+			if in.p == 1 {
+				testNodesNum(t, in.n1+in.n1*in.m, g)
+			}
+		})
+	}
+}
+
+func TestProjection(t *testing.T) {
+	// top nodes 0 to 2 and bottom nodes 3 and 4,
+	// where 0 and 1 share both bottom nodes and 1 and 2 share node 4
+	g, sides, err := newBipartiteGraph(3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pair := range [][2]string{{"0", "3"}, {"1", "3"}, {"0", "4"}, {"1", "4"}, {"2", "4"}} {
+		if err := g.AddEdge(graph.ID(pair[0]), graph.ID(pair[1]), 1.0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type input struct {
+		side       int
+		isWeighted bool
+	}
+	type output struct {
+		nodesNum int
+		weights  map[[2]graph.ID]float64
+		err      error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{
+			"success: top",
+			input{SideTop, false},
+			output{3, map[[2]graph.ID]float64{{"0", "1"}: 1, {"0", "2"}: 1, {"1", "2"}: 1}, nil},
+		},
+		{
+			"success: weighted top",
+			input{SideTop, true},
+			output{3, map[[2]graph.ID]float64{{"0", "1"}: 2, {"0", "2"}: 1, {"1", "2"}: 1}, nil},
+		},
+		{
+			"success: weighted bottom",
+			input{SideBottom, true},
+			output{2, map[[2]graph.ID]float64{{"3", "4"}: 2}, nil},
+		},
+		{
+			"failure: invalid side",
+			input{2, false},
+			output{0, nil, ErrBipartiteInvalidSide},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			proj, err := Projection(g, sides, in.side, in.isWeighted)
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testNodesNum(t, out.nodesNum, proj)
+			testEdgesNum(t, len(out.weights), proj)
+			for pair, weight := range out.weights {
+				edge, err := proj.GetEdge(pair[0], pair[1])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if edge.Weight() != weight {
+					t.Errorf("expected: %v, actual: %v", weight, edge.Weight())
+				}
+			}
+		})
+	}
+
+	// an edge within a side is rejected
+	if err := g.AddEdge("0", "2", 1.0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Projection(g, sides, SideTop, false); err != ErrNotBipartite {
+		t.Errorf("expected: %v, actual: %v", ErrNotBipartite, err)
+	}
+}
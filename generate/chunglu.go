@@ -0,0 +1,151 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrChungLuInvalidWeight     = errors.New("generate: the weight must be finite and 0 or more")
+	ErrChungLuUnbalancedWeights = errors.New("generate: the sums of the out-weights and the in-weights must be equal")
+)
+
+// ChungLu generates an undirected graph by the Chung–Lu model, in which the nodes of the given IDs
+// are connected with probability min(1, w_u * w_v / S), where S is the sum of the weights,
+// so that the expected degree of each node is about its weight.
+// It runs in O(n log n + m) time by the sampler of Miller and Hagberg.
+func ChungLu(weights map[graph.ID]float64, rng *rand.Rand) (*graph.Graph, error) {
+	sum, err := sumWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	return sampleByWeights(false, weights, weights, func(x float64) float64 {
+		if sum == 0 {
+			return 0
+		}
+		return math.Min(1, x/sum)
+	}, rng)
+}
+
+// DirectedChungLu generates a directed graph by the Chung–Lu model, in which each node u links to
+// each node v with probability min(1, wOut_u * wIn_v / S), where S is the sum of the out-weights,
+// which must be equal to the sum of the in-weights.
+// A node missing in either weights has the weight 0 in it.
+func DirectedChungLu(outWeights, inWeights map[graph.ID]float64, rng *rand.Rand) (*graph.Graph, error) {
+	outSum, err := sumWeights(outWeights)
+	if err != nil {
+		return nil, err
+	}
+	inSum, err := sumWeights(inWeights)
+	if err != nil {
+		return nil, err
+	}
+	if math.Abs(outSum-inSum) > 1e-9*math.Max(outSum, inSum) {
+		return nil, ErrChungLuUnbalancedWeights
+	}
+
+	return sampleByWeights(true, outWeights, inWeights, func(x float64) float64 {
+		if outSum == 0 {
+			return 0
+		}
+		return math.Min(1, x/outSum)
+	}, rng)
+}
+
+// SoftConfiguration generates an undirected graph by the soft configuration model,
+// the maximum entropy model with the expected degrees as constraints,
+// in which the nodes of the given IDs are connected with probability
+// x_u * x_v / (1 + x_u * x_v) of their hidden variables.
+func SoftConfiguration(hiddens map[graph.ID]float64, rng *rand.Rand) (*graph.Graph, error) {
+	if _, err := sumWeights(hiddens); err != nil {
+		return nil, err
+	}
+
+	return sampleByWeights(false, hiddens, hiddens, func(x float64) float64 {
+		return x / (1 + x)
+	}, rng)
+}
+
+func sumWeights(weights map[graph.ID]float64) (float64, error) {
+	sum := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return 0, ErrChungLuInvalidWeight
+		}
+		sum += w
+	}
+	return sum, nil
+}
+
+// sampleByWeights connects each pair of nodes u and v (u to v if directed)
+// with probability prob(outWeights[u] * inWeights[v]), where prob must be non-decreasing.
+// Scanning the nodes in descending order of the in-weights, the probability is non-increasing,
+// so that it can skip over the nodes geometrically with the current probability
+// and accept each landed node with the ratio of its probability to the current one.
+func sampleByWeights(isDirected bool, outWeights, inWeights map[graph.ID]float64, prob func(float64) float64, rng *rand.Rand) (*graph.Graph, error) {
+	g := newGraph(isDirected)
+
+	idSet := map[graph.ID]bool{}
+	for id := range outWeights {
+		idSet[id] = true
+	}
+	for id := range inWeights {
+		idSet[id] = true
+	}
+	ids := make([]graph.ID, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+		if err := g.AddNode(graph.NewNode(id.String())); err != nil {
+			return nil, err
+		}
+	}
+
+	// sort nodes in descending order of the in-weights
+	sort.Slice(ids, func(i, j int) bool {
+		if inWeights[ids[i]] != inWeights[ids[j]] {
+			return inWeights[ids[i]] > inWeights[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	n := len(ids)
+	for i, idTail := range ids {
+		wOut := outWeights[idTail]
+
+		j := 0
+		if !isDirected {
+			// scan only the nodes after the tail in the same order
+			j = i + 1
+		}
+
+		p := 1.0
+		if j < n {
+			p = prob(wOut * inWeights[ids[j]])
+		}
+		for j < n && p > 0 {
+			if p < 1 {
+				skip := math.Floor(math.Log(1-rng.Float64()) / math.Log1p(-p))
+				if !(skip < float64(n-j)) {
+					break
+				}
+				j += int(skip)
+			}
+
+			q := prob(wOut * inWeights[ids[j]])
+			if rng.Float64() < q/p && j != i {
+				if err := g.AddEdge(idTail, ids[j], 1.0); err != nil {
+					return nil, err
+				}
+			}
+			p = q
+			j++
+		}
+	}
+
+	return g, nil
+}
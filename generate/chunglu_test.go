@@ -0,0 +1,129 @@
+package generate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func newTestWeights(n int, offset float64) map[graph.ID]float64 {
+	weights := map[graph.ID]float64{}
+	for i := 0; i < n; i++ {
+		// heavy-tailed weights
+		weights[newID(i)] = offset + 50/math.Sqrt(float64(i+1))
+	}
+	return weights
+}
+
+// testExpectedEdgesNum tests the number of edges to be within 5 standard deviations
+// from the expected one given the probability of each pair.
+func testExpectedEdgesNum(t *testing.T, g *graph.Graph, prob func(idTail, idHead graph.ID) float64) {
+	ids := []graph.ID{}
+	for id := range g.GetNodes() {
+		ids = append(ids, id)
+	}
+
+	mean, variance := 0.0, 0.0
+	for _, idTail := range ids {
+		for _, idHead := range ids {
+			if idTail == idHead || (!g.IsDirected() && idTail > idHead) {
+				continue
+			}
+			p := prob(idTail, idHead)
+			mean += p
+			variance += p * (1 - p)
+		}
+	}
+
+	sd := math.Sqrt(variance)
+	if diff := math.Abs(float64(countEdges(g)) - mean); diff > 5*sd+1e-9 {
+		t.Errorf("expected: %f +/- %f, actual: %d", mean, 5*sd, countEdges(g))
+	}
+}
+
+func TestChungLu(t *testing.T) {
+	weights := newTestWeights(500, 1)
+	sum, _ := sumWeights(weights)
+
+	g, err := ChungLu(weights, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNodesNum(t, 500, g)
+	testUnitWeights(t, g)
+	testExpectedEdgesNum(t, g, func(idTail, idHead graph.ID) float64 {
+		return math.Min(1, weights[idTail]*weights[idHead]/sum)
+	})
+
+	t.Run("success: zero weights", func(t *testing.T) {
+		g, err := ChungLu(map[graph.ID]float64{"a": 0, "b": 0}, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, 2, g)
+		testEdgesNum(t, 0, g)
+	})
+
+	t.Run("success: tiny weights", func(t *testing.T) {
+		g, err := ChungLu(map[graph.ID]float64{"a": 1, "b": 1e-17, "c": 1e-17}, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, 3, g)
+		testEdgesNum(t, 0, g)
+	})
+
+	t.Run("failure: invalid weight", func(t *testing.T) {
+		if _, err := ChungLu(map[graph.ID]float64{"a": -1}, newTestRand()); err != ErrChungLuInvalidWeight {
+			t.Errorf("expected: %v, actual: %v", ErrChungLuInvalidWeight, err)
+		}
+	})
+}
+
+func TestDirectedChungLu(t *testing.T) {
+	outWeights := newTestWeights(300, 0)
+	inWeights := map[graph.ID]float64{}
+	for i := 0; i < 300; i++ {
+		// reverse the order of the weights
+		inWeights[newID(299-i)] = outWeights[newID(i)]
+	}
+	sum, _ := sumWeights(outWeights)
+
+	g, err := DirectedChungLu(outWeights, inWeights, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.IsDirected() {
+		t.Errorf("expected: %t, actual: %t", true, g.IsDirected())
+	}
+	testNodesNum(t, 300, g)
+	testUnitWeights(t, g)
+	testExpectedEdgesNum(t, g, func(idTail, idHead graph.ID) float64 {
+		return math.Min(1, outWeights[idTail]*inWeights[idHead]/sum)
+	})
+
+	t.Run("failure: unbalanced weights", func(t *testing.T) {
+		if _, err := DirectedChungLu(map[graph.ID]float64{"a": 1}, map[graph.ID]float64{"b": 2}, newTestRand()); err != ErrChungLuUnbalancedWeights {
+			t.Errorf("expected: %v, actual: %v", ErrChungLuUnbalancedWeights, err)
+		}
+	})
+}
+
+func TestSoftConfiguration(t *testing.T) {
+	hiddens := newTestWeights(300, 0)
+	for id := range hiddens {
+		hiddens[id] /= 100
+	}
+
+	g, err := SoftConfiguration(hiddens, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNodesNum(t, 300, g)
+	testUnitWeights(t, g)
+	testExpectedEdgesNum(t, g, func(idTail, idHead graph.ID) float64 {
+		x := hiddens[idTail] * hiddens[idHead]
+		return x / (1 + x)
+	})
+}
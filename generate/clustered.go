@@ -0,0 +1,211 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrClusteredInvalidM = errors.New("generate: m must be 1 or more")
+	ErrClusteredInvalidN = errors.New("generate: n must be m or more")
+)
+
+// HolmeKim generates an undirected graph of n nodes by the Holme–Kim model,
+// growing from a ring of m nodes as the BA model.
+// After the first preferential attachment of each new node, each of the other m-1 edges
+// closes a triangle with probability pt by linking to a random neighbor of the node
+// chosen by the last preferential attachment, or attaches preferentially otherwise.
+func HolmeKim(n, m int, pt float64, rng *rand.Rand) (*graph.Graph, error) {
+	if m < 1 {
+		return nil, ErrClusteredInvalidM
+	}
+	if n < m {
+		return nil, ErrClusteredInvalidN
+	}
+	if !isValidProbability(pt) {
+		return nil, ErrInvalidProbability
+	}
+
+	g := graph.NewUndirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	ends := []int{}
+	neighbors := make([][]int, n)
+	addEdge := func(v, w int) error {
+		if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+			return err
+		}
+		ends = append(ends, v, w)
+		neighbors[v] = append(neighbors[v], w)
+		neighbors[w] = append(neighbors[w], v)
+		return nil
+	}
+
+	// add default edges
+	for _, pair := range ringPairs(m) {
+		if err := addEdge(pair[0], pair[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	for v := m; v < n; v++ {
+		linked := make(map[int]bool, m)
+
+		attach := func() int {
+			for {
+				var w int
+				if len(ends) == 0 {
+					w = rng.Intn(v)
+				} else {
+					w = ends[rng.Intn(len(ends))]
+				}
+				if !linked[w] {
+					return w
+				}
+			}
+		}
+
+		w := attach()
+		linked[w] = true
+		targets := []int{w}
+		last := w
+
+		for len(targets) < m {
+			if rng.Float64() < pt {
+				// triad formation
+				candidates := []int{}
+				for _, u := range neighbors[last] {
+					if u != v && !linked[u] {
+						candidates = append(candidates, u)
+					}
+				}
+				if len(candidates) > 0 {
+					u := candidates[rng.Intn(len(candidates))]
+					linked[u] = true
+					targets = append(targets, u)
+					continue
+				}
+			}
+
+			// preferential attachment
+			w := attach()
+			linked[w] = true
+			targets = append(targets, w)
+			last = w
+		}
+
+		// add edges
+		for _, w := range targets {
+			if err := addEdge(v, w); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// KlemmEguiluz generates an undirected graph of n nodes by the Klemm–Eguíluz model,
+// growing from a complete graph of m active nodes.
+// Each new node links to each active node, or with probability mu to a node
+// chosen preferentially instead. Then the new node becomes active and
+// one of the active nodes is deactivated with probability proportional to 1 / (m + k) of the degree k.
+func KlemmEguiluz(n, m int, mu float64, rng *rand.Rand) (*graph.Graph, error) {
+	if m < 1 {
+		return nil, ErrClusteredInvalidM
+	}
+	if n < m {
+		return nil, ErrClusteredInvalidN
+	}
+	if !isValidProbability(mu) {
+		return nil, ErrInvalidProbability
+	}
+
+	g := graph.NewUndirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	ends := []int{}
+	degrees := make([]int, n)
+	addEdge := func(v, w int) error {
+		if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+			return err
+		}
+		ends = append(ends, v, w)
+		degrees[v]++
+		degrees[w]++
+		return nil
+	}
+
+	// add default edges
+	actives := make([]int, m)
+	for v := 0; v < m; v++ {
+		actives[v] = v
+		for w := v + 1; w < m; w++ {
+			if err := addEdge(v, w); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for v := m; v < n; v++ {
+		linked := make(map[int]bool, m)
+		targets := make([]int, 0, m)
+
+		rewiredNum := 0
+		for _, w := range actives {
+			if rng.Float64() < mu {
+				rewiredNum++
+				continue
+			}
+			linked[w] = true
+			targets = append(targets, w)
+		}
+
+		// preferential attachment instead of the rewired links
+		for i := 0; i < rewiredNum; i++ {
+			var w int
+			for {
+				if len(ends) == 0 {
+					w = rng.Intn(v)
+				} else {
+					w = ends[rng.Intn(len(ends))]
+				}
+				if !linked[w] {
+					break
+				}
+			}
+			linked[w] = true
+			targets = append(targets, w)
+		}
+
+		// add edges
+		for _, w := range targets {
+			if err := addEdge(v, w); err != nil {
+				return nil, err
+			}
+		}
+
+		// deactivate one of the active nodes
+		sum := 0.0
+		for _, w := range actives {
+			sum += 1 / float64(m+degrees[w])
+		}
+		x := rng.Float64() * sum
+		i := 0
+		for ; i < len(actives)-1; i++ {
+			x -= 1 / float64(m+degrees[actives[i]])
+			if x < 0 {
+				break
+			}
+		}
+		actives[i] = v
+	}
+
+	return g, nil
+}
@@ -0,0 +1,113 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func calcAverageClustering(t *testing.T, g *graph.Graph) float64 {
+	sum := 0.0
+	for id := range g.GetNodes() {
+		heads, err := g.GetHeads(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := len(heads)
+		if k < 2 {
+			continue
+		}
+
+		triangles := 0
+		for id1 := range heads {
+			for id2 := range heads {
+				if id1 >= id2 {
+					continue
+				}
+				if ok, _ := isAdjacent(g, id1, id2); ok {
+					triangles++
+				}
+			}
+		}
+		sum += float64(2*triangles) / float64(k*(k-1))
+	}
+	return sum / float64(len(g.GetNodes()))
+}
+
+func TestHolmeKim(t *testing.T) {
+	n, m := 1000, 3
+
+	gBA, err := HolmeKim(n, m, 0, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gHK, err := HolmeKim(n, m, 0.9, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, g := range []*graph.Graph{gBA, gHK} {
+		testNodesNum(t, n, g)
+		testEdgesNum(t, m+(n-m)*m, g)
+		testUnitWeights(t, g)
+	}
+
+	cBA, cHK := calcAverageClustering(t, gBA), calcAverageClustering(t, gHK)
+	if cHK <= 2*cBA {
+		t.Errorf("expected: > %f, actual: %f", 2*cBA, cHK)
+	}
+
+	t.Run("failure: invalid m", func(t *testing.T) {
+		if _, err := HolmeKim(n, 0, 0.5, newTestRand()); err != ErrClusteredInvalidM {
+			t.Errorf("expected: %v, actual: %v", ErrClusteredInvalidM, err)
+		}
+	})
+
+	t.Run("failure: invalid n", func(t *testing.T) {
+		if _, err := HolmeKim(2, 3, 0.5, newTestRand()); err != ErrClusteredInvalidN {
+			t.Errorf("expected: %v, actual: %v", ErrClusteredInvalidN, err)
+		}
+	})
+
+	t.Run("failure: invalid pt", func(t *testing.T) {
+		if _, err := HolmeKim(n, m, 1.5, newTestRand()); err != ErrInvalidProbability {
+			t.Errorf("expected: %v, actual: %v", ErrInvalidProbability, err)
+		}
+	})
+}
+
+func TestKlemmEguiluz(t *testing.T) {
+	n, m := 1000, 4
+
+	for _, mu := range []float64{0, 0.1, 1} {
+		g, err := KlemmEguiluz(n, m, mu, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, n, g)
+		testEdgesNum(t, m*(m-1)/2+(n-m)*m, g)
+		testUnitWeights(t, g)
+	}
+
+	g, err := KlemmEguiluz(n, m, 0, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := calcAverageClustering(t, g); c < 0.5 {
+		t.Errorf("expected: >= %f, actual: %f", 0.5, c)
+	}
+
+	t.Run("success: m = 1", func(t *testing.T) {
+		g, err := KlemmEguiluz(10, 1, 0.5, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testEdgesNum(t, 9, g)
+	})
+
+	t.Run("failure: invalid mu", func(t *testing.T) {
+		if _, err := KlemmEguiluz(n, m, -0.5, newTestRand()); err != ErrInvalidProbability {
+			t.Errorf("expected: %v, actual: %v", ErrInvalidProbability, err)
+		}
+	})
+}
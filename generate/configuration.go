@@ -0,0 +1,148 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrInvalidDegree       = errors.New("generate: the degree must be 0 or more")
+	ErrOddDegreeSum        = errors.New("generate: the sum of the degrees must be even")
+	ErrTooManyTrials       = errors.New("generate: failed to generate a graph within the maximum number of trials")
+	ErrInvalidMaxTrialsNum = errors.New("generate: the maximum number of trials must be 1 or more")
+)
+
+// DegreesFromDistribution expands the degree distribution into a degree sequence
+// sorted in ascending order.
+func DegreesFromDistribution(dist *graph.DegreeDistribution) []int {
+	ks := append([]int{}, dist.GetDegrees()...)
+	sort.Ints(ks)
+
+	degrees := []int{}
+	for _, k := range ks {
+		for i := 0; i < dist.GetNum(k); i++ {
+			degrees = append(degrees, k)
+		}
+	}
+
+	return degrees
+}
+
+// Configuration generates an undirected graph whose node i has degrees[i] stubs
+// by the erased configuration model, which pairs the stubs at random and
+// discards the pairs that would form loops or multi-edges.
+// It also returns the number of the discarded stubs.
+func Configuration(degrees []int, rng *rand.Rand) (*graph.Graph, int, error) {
+	if err := validateDegrees(degrees); err != nil {
+		return nil, 0, err
+	}
+
+	g := graph.NewUndirected()
+	if err := addNodes(g, len(degrees)); err != nil {
+		return nil, 0, err
+	}
+
+	discarded := 0
+
+	stubs := newShuffledStubs(degrees, rng)
+	for i := 0; i < len(stubs); i += 2 {
+		idTail, idHead := newID(stubs[i]), newID(stubs[i+1])
+		if idTail == idHead {
+			discarded += 2
+			continue
+		}
+		if _, err := g.GetEdge(idTail, idHead); err == nil {
+			discarded += 2
+			continue
+		}
+
+		if err := g.AddEdge(idTail, idHead, 1.0); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return g, discarded, nil
+}
+
+// SimpleConfiguration generates a simple undirected graph whose node i has degree degrees[i]
+// by pairing the stubs at random and rejecting the pairings with loops or multi-edges.
+// It gives up after maxTrialsNum pairings.
+func SimpleConfiguration(degrees []int, maxTrialsNum int, rng *rand.Rand) (*graph.Graph, error) {
+	if err := validateDegrees(degrees); err != nil {
+		return nil, err
+	}
+	if maxTrialsNum < 1 {
+		return nil, ErrInvalidMaxTrialsNum
+	}
+
+	for trial := 0; trial < maxTrialsNum; trial++ {
+		stubs := newShuffledStubs(degrees, rng)
+		if !isSimplePairing(stubs) {
+			continue
+		}
+
+		g := graph.NewUndirected()
+		if err := addNodes(g, len(degrees)); err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(stubs); i += 2 {
+			if err := g.AddEdge(newID(stubs[i]), newID(stubs[i+1]), 1.0); err != nil {
+				return nil, err
+			}
+		}
+
+		return g, nil
+	}
+
+	return nil, ErrTooManyTrials
+}
+
+func validateDegrees(degrees []int) error {
+	sum := 0
+	for _, k := range degrees {
+		if k < 0 {
+			return ErrInvalidDegree
+		}
+		sum += k
+	}
+	if sum%2 != 0 {
+		return ErrOddDegreeSum
+	}
+	return nil
+}
+
+func newShuffledStubs(degrees []int, rng *rand.Rand) []int {
+	stubs := []int{}
+	for i, k := range degrees {
+		for j := 0; j < k; j++ {
+			stubs = append(stubs, i)
+		}
+	}
+
+	rng.Shuffle(len(stubs), func(i, j int) {
+		stubs[i], stubs[j] = stubs[j], stubs[i]
+	})
+
+	return stubs
+}
+
+func isSimplePairing(stubs []int) bool {
+	pairs := make(map[[2]int]bool, len(stubs)/2)
+	for i := 0; i < len(stubs); i += 2 {
+		v, w := stubs[i], stubs[i+1]
+		if v == w {
+			return false
+		}
+		if v > w {
+			v, w = w, v
+		}
+		if pairs[[2]int{v, w}] {
+			return false
+		}
+		pairs[[2]int{v, w}] = true
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func TestDegreesFromDistribution(t *testing.T) {
+	dist := graph.NewDegreeDistribution()
+	for _, k := range []int{3, 1, 2, 1} {
+		dist.Add(k)
+	}
+
+	expected := []int{1, 1, 2, 3}
+	actual := DegreesFromDistribution(dist)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected: %d, actual: %d", len(expected), len(actual))
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("expected: %d, actual: %d", expected[i], actual[i])
+		}
+	}
+}
+
+func TestConfiguration(t *testing.T) {
+	type input struct {
+		degrees []int
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{
+			"success",
+			input{[]int{3, 3, 2, 2, 2, 1, 1}},
+			output{nil},
+		},
+		{
+			"success: loops only",
+			input{[]int{4}},
+			output{nil},
+		},
+		{
+			"success: empty",
+			input{[]int{}},
+			output{nil},
+		},
+		{
+			"failure: negative degree",
+			input{[]int{1, -1}},
+			output{ErrInvalidDegree},
+		},
+		{
+			"failure: odd sum",
+			input{[]int{1, 1, 1}},
+			output{ErrOddDegreeSum},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, discarded, err := Configuration(in.degrees, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testNodesNum(t, len(in.degrees), g)
+			testUnitWeights(t, g)
+
+			// every stub is either used by an edge or discarded
+			sum := 0
+			for i, k := range in.degrees {
+				sum += k
+				if actual := degree(t, g, newID(i)); actual > k {
+					t.Errorf("expected: <= %d, actual: %d", k, actual)
+				}
+			}
+			if 2*countEdges(g)+discarded != sum {
+				t.Errorf("expected: %d, actual: %d", sum, 2*countEdges(g)+discarded)
+			}
+		})
+	}
+}
+
+func TestSimpleConfiguration(t *testing.T) {
+	degrees := []int{3, 3, 2, 2, 2, 1, 1}
+
+	g, err := SimpleConfiguration(degrees, 1000, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testUnitWeights(t, g)
+	for i, k := range degrees {
+		if actual := degree(t, g, newID(i)); actual != k {
+			t.Errorf("expected: %d, actual: %d", k, actual)
+		}
+	}
+
+	t.Run("failure: not graphical", func(t *testing.T) {
+		if _, err := SimpleConfiguration([]int{2}, 10, newTestRand()); err != ErrTooManyTrials {
+			t.Errorf("expected: %v, actual: %v", ErrTooManyTrials, err)
+		}
+	})
+
+	t.Run("failure: invalid max trials num", func(t *testing.T) {
+		if _, err := SimpleConfiguration(degrees, 0, newTestRand()); err != ErrInvalidMaxTrialsNum {
+			t.Errorf("expected: %v, actual: %v", ErrInvalidMaxTrialsNum, err)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrCopyingInvalidD = errors.New("generate: d must be 1 or more")
+	ErrCopyingInvalidN = errors.New("generate: n must be more than d")
+)
+
+// Copying generates a directed graph of n nodes by the copying model of Kleinberg and Kumar et al.,
+// growing from a complete directed graph of d + 1 nodes.
+// Each new node chooses a prototype uniformly and creates d links, each of which
+// heads to a node chosen uniformly with probability alpha, or copies the corresponding link of the prototype.
+func Copying(n, d int, alpha float64, rng *rand.Rand) (*graph.Graph, error) {
+	if d < 1 {
+		return nil, ErrCopyingInvalidD
+	}
+	if n <= d {
+		return nil, ErrCopyingInvalidN
+	}
+	if !isValidProbability(alpha) {
+		return nil, ErrInvalidProbability
+	}
+
+	g := graph.NewDirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	// the heads of each node in the order of their links
+	heads := make([][]int, n)
+
+	// add default edges
+	for v := 0; v <= d; v++ {
+		for w := 0; w <= d; w++ {
+			if v == w {
+				continue
+			}
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+			heads[v] = append(heads[v], w)
+		}
+	}
+
+	for v := d + 1; v < n; v++ {
+		prototype := rng.Intn(v)
+
+		linked := make(map[int]bool, d)
+		for i := 0; i < d; i++ {
+			var w int
+			if rng.Float64() < alpha {
+				w = rng.Intn(v)
+			} else {
+				w = heads[prototype][i]
+			}
+			for linked[w] {
+				// avoid duplicated links
+				w = rng.Intn(v)
+			}
+			linked[w] = true
+
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+			heads[v] = append(heads[v], w)
+		}
+	}
+
+	return g, nil
+}
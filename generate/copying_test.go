@@ -0,0 +1,75 @@
+package generate
+
+import "testing"
+
+func TestCopying(t *testing.T) {
+	type input struct {
+		n, d  int
+		alpha float64
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{
+			"success",
+			input{500, 3, 0.2},
+			output{nil},
+		},
+		{
+			"success: copy only",
+			input{100, 2, 0},
+			output{nil},
+		},
+		{
+			"success: random only",
+			input{100, 2, 1},
+			output{nil},
+		},
+		{
+			"failure: invalid d",
+			input{10, 0, 0.5},
+			output{ErrCopyingInvalidD},
+		},
+		{
+			"failure: invalid n",
+			input{3, 3, 0.5},
+			output{ErrCopyingInvalidN},
+		},
+		{
+			"failure: invalid alpha",
+			input{10, 2, 1.5},
+			output{ErrInvalidProbability},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := Copying(in.n, in.d, in.alpha, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !g.IsDirected() {
+				t.Errorf("expected: %t, actual: %t", true, g.IsDirected())
+			}
+			testNodesNum(t, in.n, g)
+			testEdgesNum(t, in.n*in.d, g)
+			testUnitWeights(t, g)
+			for v := 0; v < in.n; v++ {
+				if k := degree(t, g, newID(v)); k != in.d {
+					t.Errorf("expected: %d, actual: %d", in.d, k)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrDuplicationInvalidSeed    = errors.New("generate: the seed graph must be undirected and have 1 or more nodes")
+	ErrDuplicationInvalidN       = errors.New("generate: n must be the number of the nodes of the seed graph or more")
+	ErrDuplicationInvalidVariant = errors.New("generate: the duplication variant is invalid")
+	ErrDuplicationInvalidAlpha   = errors.New("generate: alpha must be 0 or more")
+)
+
+type DuplicationVariant int
+
+const (
+	// DuplicationSole keeps each edge copied to the duplicate with the retention probability,
+	// leaving the edges of the original node as they are,
+	// and links the duplicate to each of the other N nodes with probability alpha / N
+	// as in the model of Solé et al.
+	DuplicationSole DuplicationVariant = iota
+
+	// DuplicationVazquez copies all the edges to the duplicate, and then for each of the pairs of
+	// the original and the copied edges, removes one of them chosen uniformly
+	// with probability 1 - retention.
+	DuplicationVazquez
+)
+
+// DuplicationDivergence generates an undirected graph of n nodes by the duplication–divergence model,
+// growing from a copy of the seed graph, or a pair of connected nodes 0 and 1 if seed is nil.
+// At each step a node chosen uniformly is duplicated, the edges diverge by the variant,
+// and the duplicate links to the original node with probability parentProb.
+// alpha is used only by DuplicationSole.
+// The new nodes are numbered from the number of the nodes of the seed graph
+// skipping the IDs in it, and can be isolated.
+func DuplicationDivergence(n int, variant DuplicationVariant, retention, parentProb, alpha float64, seed *graph.Graph, rng *rand.Rand) (*graph.Graph, error) {
+	if variant != DuplicationSole && variant != DuplicationVazquez {
+		return nil, ErrDuplicationInvalidVariant
+	}
+	if !isValidProbability(retention) || !isValidProbability(parentProb) {
+		return nil, ErrInvalidProbability
+	}
+	if alpha < 0 || math.IsNaN(alpha) || math.IsInf(alpha, 0) {
+		return nil, ErrDuplicationInvalidAlpha
+	}
+
+	var g *graph.Graph
+	if seed == nil {
+		g = graph.NewUndirected()
+		if err := addNodes(g, 2); err != nil {
+			return nil, err
+		}
+		if err := g.AddEdge(newID(0), newID(1), 1.0); err != nil {
+			return nil, err
+		}
+	} else {
+		if seed.IsDirected() || len(seed.GetNodes()) == 0 {
+			return nil, ErrDuplicationInvalidSeed
+		}
+		g = seed.Copy()
+	}
+
+	if n < len(g.GetNodes()) {
+		return nil, ErrDuplicationInvalidN
+	}
+
+	ids := make([]graph.ID, 0, n)
+	for id := range g.GetNodes() {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	next := len(ids)
+	for len(ids) < n {
+		// add the duplicate
+		idNew := newID(next)
+		for {
+			if _, err := g.GetNode(idNew); err != nil {
+				break
+			}
+			next++
+			idNew = newID(next)
+		}
+		next++
+		if err := g.AddNode(graph.NewNode(idNew.String())); err != nil {
+			return nil, err
+		}
+
+		idOrig := ids[rng.Intn(len(ids))]
+		ids = append(ids, idNew)
+
+		heads, err := g.GetHeads(idOrig)
+		if err != nil {
+			return nil, err
+		}
+		neighbors := make([]graph.ID, 0, len(heads))
+		for id := range heads {
+			neighbors = append(neighbors, id)
+		}
+		sort.Slice(neighbors, func(i, j int) bool {
+			return neighbors[i] < neighbors[j]
+		})
+
+		for _, id := range neighbors {
+			isDiverged := rng.Float64() >= retention
+
+			if variant == DuplicationSole && isDiverged {
+				continue
+			}
+			if variant == DuplicationVazquez && isDiverged && rng.Intn(2) == 0 {
+				// remove the original edge instead of the copied one
+				if err := g.RemoveEdge(idOrig, id); err != nil {
+					return nil, err
+				}
+				isDiverged = false
+			}
+			if isDiverged {
+				continue
+			}
+
+			if err := g.AddEdge(idNew, id, 1.0); err != nil {
+				return nil, err
+			}
+		}
+
+		if rng.Float64() < parentProb {
+			if err := g.AddEdge(idNew, idOrig, 1.0); err != nil {
+				return nil, err
+			}
+		}
+
+		if variant == DuplicationSole && alpha > 0 {
+			// link to the other nodes at random, skipping the ones already linked
+			others := ids[:len(ids)-1]
+			p := math.Min(1, alpha/float64(len(others)))
+			if err := sampleIndices(int64(len(others)), p, rng, func(t int64) error {
+				id := others[t]
+				if ok, err := isAdjacent(g, idNew, id); err != nil || ok {
+					return err
+				}
+				return g.AddEdge(idNew, id, 1.0)
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
@@ -0,0 +1,85 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func TestDuplicationDivergence(t *testing.T) {
+	seed := graph.NewUndirected()
+	for _, id := range []string{"1", "2", "a"} {
+		if err := seed.AddNode(graph.NewNode(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := seed.AddEdge("1", "2", 1.0); err != nil {
+		t.Fatal(err)
+	}
+	if err := seed.AddEdge("2", "a", 1.0); err != nil {
+		t.Fatal(err)
+	}
+
+	type input struct {
+		n          int
+		variant    DuplicationVariant
+		retention  float64
+		parentProb float64
+		alpha      float64
+		seed       *graph.Graph
+	}
+	type output struct {
+		edgesNum int // -1 if random
+		err      error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{"success: sole", input{100, DuplicationSole, 0.5, 0.5, 1, nil}, output{-1, nil}},
+		{"success: sole linking to all", input{20, DuplicationSole, 0, 0, 1e9, nil}, output{190, nil}},
+		{"success: vazquez ignoring alpha", input{100, DuplicationVazquez, 0, 0, 1e9, nil}, output{1, nil}},
+		{"success: vazquez", input{100, DuplicationVazquez, 0.5, 0.5, 0, nil}, output{-1, nil}},
+		{"success: sole tree", input{100, DuplicationSole, 0, 1, 0, nil}, output{99, nil}},
+		{"success: vazquez conserving edges", input{100, DuplicationVazquez, 0, 0, 0, nil}, output{1, nil}},
+		{"success: seed", input{50, DuplicationSole, 0, 1, 0, seed}, output{49, nil}},
+		{"success: seed only", input{3, DuplicationSole, 0.5, 0.5, 0, seed}, output{2, nil}},
+		{"failure: invalid variant", input{10, DuplicationVariant(2), 0.5, 0.5, 0, nil}, output{0, ErrDuplicationInvalidVariant}},
+		{"failure: invalid retention", input{10, DuplicationSole, 1.5, 0.5, 0, nil}, output{0, ErrInvalidProbability}},
+		{"failure: invalid parent probability", input{10, DuplicationSole, 0.5, -0.1, 0, nil}, output{0, ErrInvalidProbability}},
+		{"failure: invalid alpha", input{10, DuplicationSole, 0.5, 0.5, -1, nil}, output{0, ErrDuplicationInvalidAlpha}},
+		{"failure: directed seed", input{10, DuplicationSole, 0.5, 0.5, 0, graph.NewDirected()}, output{0, ErrDuplicationInvalidSeed}},
+		{"failure: empty seed", input{10, DuplicationSole, 0.5, 0.5, 0, graph.NewUndirected()}, output{0, ErrDuplicationInvalidSeed}},
+		{"failure: n less than seed", input{1, DuplicationSole, 0.5, 0.5, 0, nil}, output{0, ErrDuplicationInvalidN}},
+		{"failure: negative n", input{-1, DuplicationSole, 0.5, 0.5, 0, nil}, output{0, ErrDuplicationInvalidN}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := DuplicationDivergence(in.n, in.variant, in.retention, in.parentProb, in.alpha, in.seed, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testNodesNum(t, in.n, g)
+			if out.edgesNum >= 0 {
+				testEdgesNum(t, out.edgesNum, g)
+			}
+			testUnitWeights(t, g)
+		})
+	}
+
+	// the seed graph is left as it is
+	if n := len(seed.GetNodes()); n != 3 {
+		t.Errorf("expected: %d, actual: %d", 3, n)
+	}
+	if n := countEdges(seed); n != 2 {
+		t.Errorf("expected: %d, actual: %d", 2, n)
+	}
+}
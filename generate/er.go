@@ -0,0 +1,151 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrERInvalidN = errors.New("generate: n must be 0 or more")
+	ErrERInvalidM = errors.New("generate: m must be between 0 and the number of possible edges")
+)
+
+// GNP generates a graph of n nodes by the ER model G(n, p),
+// in which each possible edge exists independently with probability p.
+// It runs in O(n + m) time by skipping over the absent edges geometrically.
+func GNP(n int, p float64, isDirected bool, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrERInvalidN
+	}
+	if !isValidProbability(p) {
+		return nil, ErrInvalidProbability
+	}
+
+	g := newGraph(isDirected)
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	if err := sampleIndices(maxEdgesNum(n, isDirected), p, rng, func(t int64) error {
+		v, w := pairFromIndex(t, n, isDirected)
+		return g.AddEdge(newID(v), newID(w), 1.0)
+	}); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// GNM generates a graph of n nodes by the ER model G(n, m),
+// in which m edges are chosen uniformly from all the possible edges.
+func GNM(n, m int, isDirected bool, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrERInvalidN
+	}
+
+	total := maxEdgesNum(n, isDirected)
+	if m < 0 || int64(m) > total {
+		return nil, ErrERInvalidM
+	}
+
+	g := newGraph(isDirected)
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	// pick the absent edges instead when the graph is dense
+	isComplement := int64(m) > total/2
+	pickedNum := int64(m)
+	if isComplement {
+		pickedNum = total - int64(m)
+	}
+
+	picked := make(map[int64]bool, pickedNum)
+	for int64(len(picked)) < pickedNum {
+		picked[rng.Int63n(total)] = true
+	}
+
+	if isComplement {
+		for t := int64(0); t < total; t++ {
+			if picked[t] {
+				continue
+			}
+			v, w := pairFromIndex(t, n, isDirected)
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for t := range picked {
+			v, w := pairFromIndex(t, n, isDirected)
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// sampleIndices calls f for each index in [0, total) independently with probability p.
+// It runs in O(1 + total * p) time by skipping over the indices geometrically.
+func sampleIndices(total int64, p float64, rng *rand.Rand, f func(int64) error) error {
+	if p == 0 {
+		return nil
+	}
+
+	// Log1p keeps the logarithm non-zero for tiny p
+	lp := math.Log1p(-p)
+
+	for t := int64(-1); ; {
+		// skip the indices that are not sampled, stopping also on a non-finite skip
+		skip := math.Floor(math.Log(1-rng.Float64()) / lp)
+		if !(skip < float64(total-t-1)) {
+			break
+		}
+		t += 1 + int64(skip)
+
+		if err := f(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func maxEdgesNum(n int, isDirected bool) int64 {
+	total := int64(n) * int64(n-1)
+	if !isDirected {
+		total /= 2
+	}
+	if total < 0 {
+		return 0
+	}
+	return total
+}
+
+// pairFromIndex maps an index in [0, maxEdgesNum(n, isDirected)) to an edge.
+// Directed edges are numbered row by row without loops,
+// undirected edges (v, w) with w < v are numbered in lower triangular order.
+func pairFromIndex(t int64, n int, isDirected bool) (v, w int) {
+	if isDirected {
+		v, w = int(t/int64(n-1)), int(t%int64(n-1))
+		if w >= v {
+			w++
+		}
+		return
+	}
+
+	v = int((1 + math.Sqrt(1+8*float64(t))) / 2)
+	for int64(v)*int64(v-1)/2 > t {
+		v--
+	}
+	for int64(v+1)*int64(v)/2 <= t {
+		v++
+	}
+	w = int(t - int64(v)*int64(v-1)/2)
+	return
+}
@@ -0,0 +1,204 @@
+package generate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGNP(t *testing.T) {
+	type input struct {
+		n          int
+		p          float64
+		isDirected bool
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{
+			"success: directed",
+			input{1000, 0.01, true},
+			output{nil},
+		},
+		{
+			"success: undirected",
+			input{1000, 0.01, false},
+			output{nil},
+		},
+		{
+			"success: n = 0",
+			input{0, 0.5, false},
+			output{nil},
+		},
+		{
+			"failure: invalid n",
+			input{-1, 0.5, false},
+			output{ErrERInvalidN},
+		},
+		{
+			"failure: invalid p",
+			input{10, -0.1, false},
+			output{ErrInvalidProbability},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := GNP(in.n, in.p, in.isDirected, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if g.IsDirected() != in.isDirected {
+				t.Errorf("expected: %t, actual: %t", in.isDirected, g.IsDirected())
+			}
+			testNodesNum(t, in.n, g)
+			testUnitWeights(t, g)
+
+			// the number of edges should be within 5 standard deviations
+			total := float64(maxEdgesNum(in.n, in.isDirected))
+			mean := total * in.p
+			sd := math.Sqrt(total * in.p * (1 - in.p))
+			if diff := math.Abs(float64(countEdges(g)) - mean); diff > 5*sd {
+				t.Errorf("expected: %f +/- %f, actual: %d", mean, 5*sd, countEdges(g))
+			}
+		})
+	}
+}
+
+func TestGNP_Extremes(t *testing.T) {
+	for _, isDirected := range []bool{true, false} {
+		g, err := GNP(10, 0, isDirected, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testEdgesNum(t, 0, g)
+
+		g, err = GNP(10, 1, isDirected, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testEdgesNum(t, int(maxEdgesNum(10, isDirected)), g)
+
+		// 1 - p rounds to 1 for tiny p
+		for _, p := range []float64{5e-17, 5e-324} {
+			g, err = GNP(100, p, isDirected, newTestRand())
+			if err != nil {
+				t.Fatal(err)
+			}
+			testEdgesNum(t, 0, g)
+		}
+	}
+}
+
+func TestSampleIndices(t *testing.T) {
+	cnt := 0
+	if err := sampleIndices(1<<40, 1e-17, newTestRand(), func(t int64) error {
+		cnt++
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if cnt > 1 {
+		t.Errorf("expected: 1 or less, actual: %d", cnt)
+	}
+}
+
+func TestGNM(t *testing.T) {
+	type input struct {
+		n, m       int
+		isDirected bool
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{
+			"success: directed",
+			input{100, 300, true},
+			output{nil},
+		},
+		{
+			"success: undirected",
+			input{100, 300, false},
+			output{nil},
+		},
+		{
+			"success: dense",
+			input{10, 40, false},
+			output{nil},
+		},
+		{
+			"success: complete",
+			input{10, 90, true},
+			output{nil},
+		},
+		{
+			"failure: invalid n",
+			input{-1, 0, false},
+			output{ErrERInvalidN},
+		},
+		{
+			"failure: too many edges",
+			input{10, 46, false},
+			output{ErrERInvalidM},
+		},
+		{
+			"failure: negative m",
+			input{10, -1, true},
+			output{ErrERInvalidM},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := GNM(in.n, in.m, in.isDirected, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testNodesNum(t, in.n, g)
+			testEdgesNum(t, in.m, g)
+			testUnitWeights(t, g)
+		})
+	}
+}
+
+func TestPairFromIndex(t *testing.T) {
+	n := 7
+
+	for _, isDirected := range []bool{true, false} {
+		seen := map[[2]int]bool{}
+		for i := int64(0); i < maxEdgesNum(n, isDirected); i++ {
+			v, w := pairFromIndex(i, n, isDirected)
+			if v == w || v < 0 || v >= n || w < 0 || w >= n {
+				t.Errorf("invalid pair: (%d, %d)", v, w)
+			}
+			if !isDirected && w > v {
+				t.Errorf("invalid pair: (%d, %d)", v, w)
+			}
+			if seen[[2]int{v, w}] {
+				t.Errorf("duplicated pair: (%d, %d)", v, w)
+			}
+			seen[[2]int{v, w}] = true
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrERGMDirected          = errors.New("generate: the graph for the ERGM must be undirected")
+	ErrERGMTooFewNodes       = errors.New("generate: the graph for the ERGM must have 2 or more nodes")
+	ErrERGMLengthMismatch    = errors.New("generate: the numbers of the terms and the parameters must be the same")
+	ErrERGMInvalidBurnIn     = errors.New("generate: the burn-in must be 0 or more")
+	ErrERGMInvalidThinning   = errors.New("generate: the thinning must be 1 or more")
+	ErrERGMInvalidKStarsK    = errors.New("generate: k of the k-stars must be 1 or more")
+	ErrERGMInvalidGWESPDecay = errors.New("generate: the decay of the GWESP must be 0 or more")
+)
+
+// ERGMTerm is a sufficient statistic of an exponential random graph model on undirected graphs.
+type ERGMTerm interface {
+	// Stat calculates the statistic of the graph.
+	Stat(g *graph.Graph) (float64, error)
+
+	// Delta calculates the change of the statistic by adding the edge between v and w,
+	// regarding the edge as absent even if it exists in the graph.
+	Delta(g *graph.Graph, v, w graph.ID) (float64, error)
+}
+
+type edgesTerm struct{}
+
+// EdgesTerm returns the term of the number of the edges.
+func EdgesTerm() ERGMTerm {
+	return edgesTerm{}
+}
+
+func (term edgesTerm) Stat(g *graph.Graph) (float64, error) {
+	sum := 0
+	for _, heads := range g.GetEdges() {
+		sum += len(heads)
+	}
+	return float64(sum) / 2, nil
+}
+
+func (term edgesTerm) Delta(g *graph.Graph, v, w graph.ID) (float64, error) {
+	return 1, nil
+}
+
+type trianglesTerm struct{}
+
+// TrianglesTerm returns the term of the number of the triangles.
+func TrianglesTerm() ERGMTerm {
+	return trianglesTerm{}
+}
+
+func (term trianglesTerm) Stat(g *graph.Graph) (float64, error) {
+	sum := 0
+	for v, heads := range g.GetEdges() {
+		for w := range heads {
+			if w < v {
+				continue
+			}
+			partners, err := sharedPartners(g, v, w)
+			if err != nil {
+				return 0, err
+			}
+			sum += partners
+		}
+	}
+	return float64(sum) / 3, nil
+}
+
+func (term trianglesTerm) Delta(g *graph.Graph, v, w graph.ID) (float64, error) {
+	partners, err := sharedPartners(g, v, w)
+	return float64(partners), err
+}
+
+type kStarsTerm struct {
+	k int
+}
+
+// KStarsTerm returns the term of the number of the k-stars,
+// the sum of C(d, k) over the degrees d of the nodes.
+func KStarsTerm(k int) (ERGMTerm, error) {
+	if k < 1 {
+		return nil, ErrERGMInvalidKStarsK
+	}
+	return kStarsTerm{k}, nil
+}
+
+func (term kStarsTerm) Stat(g *graph.Graph) (float64, error) {
+	sum := 0.0
+	for _, heads := range g.GetEdges() {
+		sum += binomial(len(heads), term.k)
+	}
+	return sum, nil
+}
+
+func (term kStarsTerm) Delta(g *graph.Graph, v, w graph.ID) (float64, error) {
+	sum := 0.0
+	for _, pair := range [][2]graph.ID{{v, w}, {w, v}} {
+		heads, err := g.GetHeads(pair[0])
+		if err != nil {
+			return 0, err
+		}
+		d := len(heads)
+		if _, ok := heads[pair[1]]; ok {
+			d--
+		}
+		sum += binomial(d, term.k-1)
+	}
+	return sum, nil
+}
+
+type gwespTerm struct {
+	decay float64
+}
+
+// GWESPTerm returns the term of the geometrically weighted edgewise shared partners,
+// the sum of e^α * (1 - (1 - e^-α)^p) over the edges with p shared partners,
+// where α is the decay.
+func GWESPTerm(decay float64) (ERGMTerm, error) {
+	if decay < 0 || math.IsNaN(decay) || math.IsInf(decay, 0) {
+		return nil, ErrERGMInvalidGWESPDecay
+	}
+	return gwespTerm{decay}, nil
+}
+
+func (term gwespTerm) weight(partners int) float64 {
+	return math.Exp(term.decay) * (1 - math.Pow(1-math.Exp(-term.decay), float64(partners)))
+}
+
+func (term gwespTerm) Stat(g *graph.Graph) (float64, error) {
+	sum := 0.0
+	for v, heads := range g.GetEdges() {
+		for w := range heads {
+			if w < v {
+				continue
+			}
+			partners, err := sharedPartners(g, v, w)
+			if err != nil {
+				return 0, err
+			}
+			sum += term.weight(partners)
+		}
+	}
+	return sum, nil
+}
+
+func (term gwespTerm) Delta(g *graph.Graph, v, w graph.ID) (float64, error) {
+	headsV, err := g.GetHeads(v)
+	if err != nil {
+		return 0, err
+	}
+	headsW, err := g.GetHeads(w)
+	if err != nil {
+		return 0, err
+	}
+	_, isExist := headsV[w]
+
+	partners := 0
+	sum := 0.0
+	for x := range headsV {
+		if _, ok := headsW[x]; !ok {
+			continue
+		}
+		partners++
+
+		// the edges to the shared partner gain the other of v and w as a shared partner
+		for _, y := range []graph.ID{v, w} {
+			p, err := sharedPartners(g, y, x)
+			if err != nil {
+				return 0, err
+			}
+			if isExist {
+				p--
+			}
+			sum += term.weight(p+1) - term.weight(p)
+		}
+	}
+
+	return sum + term.weight(partners), nil
+}
+
+// sharedPartners counts the nodes adjacent to both v and w.
+func sharedPartners(g *graph.Graph, v, w graph.ID) (int, error) {
+	headsV, err := g.GetHeads(v)
+	if err != nil {
+		return 0, err
+	}
+	headsW, err := g.GetHeads(w)
+	if err != nil {
+		return 0, err
+	}
+	if len(headsW) < len(headsV) {
+		headsV, headsW = headsW, headsV
+	}
+
+	partners := 0
+	for x := range headsV {
+		if _, ok := headsW[x]; ok {
+			partners++
+		}
+	}
+	return partners, nil
+}
+
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	c := 1.0
+	for i := 0; i < k; i++ {
+		c = c * float64(n-i) / float64(i+1)
+	}
+	return c
+}
+
+// ERGMConfig is the configuration of the ERGM sampler,
+// in which the probability of a graph is proportional to exp(Σ Thetas[i] * Terms[i].Stat).
+type ERGMConfig struct {
+	Terms    []ERGMTerm
+	Thetas   []float64
+	BurnIn   int // number of the toggles before the first sample
+	Thinning int // number of the toggles between the samples
+}
+
+// ERGMSampler samples undirected graphs from an ERGM by the Metropolis–Hastings algorithm,
+// proposing to toggle the edge between a pair of nodes chosen uniformly at each step.
+type ERGMSampler struct {
+	g        *graph.Graph
+	ids      []graph.ID
+	cfg      ERGMConfig
+	stats    []float64
+	rng      *rand.Rand
+	isBurned bool
+	err      error
+}
+
+// NewERGMSampler returns a sampler starting from a copy of the graph,
+// whose nodes are kept through the sampling.
+func NewERGMSampler(g *graph.Graph, cfg ERGMConfig, rng *rand.Rand) (*ERGMSampler, error) {
+	if g.IsDirected() {
+		return nil, ErrERGMDirected
+	}
+	if len(cfg.Terms) != len(cfg.Thetas) {
+		return nil, ErrERGMLengthMismatch
+	}
+	if cfg.BurnIn < 0 {
+		return nil, ErrERGMInvalidBurnIn
+	}
+	if cfg.Thinning < 1 {
+		return nil, ErrERGMInvalidThinning
+	}
+
+	ids := make([]graph.ID, 0, len(g.GetNodes()))
+	for id := range g.GetNodes() {
+		ids = append(ids, id)
+	}
+	if len(ids) < 2 {
+		return nil, ErrERGMTooFewNodes
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	s := &ERGMSampler{
+		g:     g.Copy(),
+		ids:   ids,
+		cfg:   cfg,
+		stats: make([]float64, len(cfg.Terms)),
+		rng:   rng,
+	}
+	for i, term := range cfg.Terms {
+		stat, err := term.Stat(s.g)
+		if err != nil {
+			return nil, err
+		}
+		s.stats[i] = stat
+	}
+
+	return s, nil
+}
+
+// Next advances the sampler to the next sample, running the burn-in before the first one.
+// It returns false when an error occurs, which can be retrieved by Err.
+func (s *ERGMSampler) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	steps := s.cfg.Thinning
+	if !s.isBurned {
+		steps = s.cfg.BurnIn
+		s.isBurned = true
+	}
+
+	for i := 0; i < steps; i++ {
+		if err := s.toggle(); err != nil {
+			s.err = err
+			return false
+		}
+	}
+
+	return true
+}
+
+// Graph returns a copy of the current sample.
+func (s *ERGMSampler) Graph() *graph.Graph {
+	return s.g.Copy()
+}
+
+// Stats returns the statistics of the current sample in the order of the terms.
+func (s *ERGMSampler) Stats() []float64 {
+	return append([]float64{}, s.stats...)
+}
+
+// Err returns the error that stopped the sampler.
+func (s *ERGMSampler) Err() error {
+	return s.err
+}
+
+func (s *ERGMSampler) toggle() error {
+	i := s.rng.Intn(len(s.ids))
+	j := s.rng.Intn(len(s.ids) - 1)
+	if j >= i {
+		j++
+	}
+	v, w := s.ids[i], s.ids[j]
+
+	isExist, err := isAdjacent(s.g, v, w)
+	if err != nil {
+		return err
+	}
+	sign := 1.0
+	if isExist {
+		sign = -1.0
+	}
+
+	deltas := make([]float64, len(s.cfg.Terms))
+	logRatio := 0.0
+	for k, term := range s.cfg.Terms {
+		delta, err := term.Delta(s.g, v, w)
+		if err != nil {
+			return err
+		}
+		deltas[k] = sign * delta
+		logRatio += s.cfg.Thetas[k] * deltas[k]
+	}
+
+	if logRatio < 0 && s.rng.Float64() >= math.Exp(logRatio) {
+		return nil
+	}
+
+	if isExist {
+		err = s.g.RemoveEdge(v, w)
+	} else {
+		err = s.g.AddEdge(v, w, 1.0)
+	}
+	if err != nil {
+		return err
+	}
+	for k := range s.stats {
+		s.stats[k] += deltas[k]
+	}
+
+	return nil
+}
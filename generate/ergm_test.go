@@ -0,0 +1,213 @@
+package generate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func newTestERGMTerms(t *testing.T) []ERGMTerm {
+	kStars, err := KStarsTerm(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gwesp, err := GWESPTerm(0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []ERGMTerm{EdgesTerm(), TrianglesTerm(), kStars, gwesp}
+}
+
+func TestERGMTerm_Stat(t *testing.T) {
+	g, err := Complete(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gwesp, err := GWESPTerm(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kStars, err := KStarsTerm(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		term     ERGMTerm
+		expected float64
+	}{
+		{"edges", EdgesTerm(), 6},
+		{"triangles", TrianglesTerm(), 4},
+		{"k-stars", kStars, 4},
+		{"gwesp", gwesp, 6},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stat, err := tc.term.Stat(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(stat-tc.expected) > 1e-9 {
+				t.Errorf("expected: %v, actual: %v", tc.expected, stat)
+			}
+		})
+	}
+}
+
+func TestERGMTerm_Delta(t *testing.T) {
+	g, err := GNP(10, 0.4, false, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, term := range newTestERGMTerms(t) {
+		for v := 0; v < 10; v++ {
+			for w := v + 1; w < 10; w++ {
+				idV, idW := newID(v), newID(w)
+
+				isExist, err := isAdjacent(g, idV, idW)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// the statistics with and without the edge
+				if !isExist {
+					if err := g.AddEdge(idV, idW, 1.0); err != nil {
+						t.Fatal(err)
+					}
+				}
+				with, err := term.Stat(g)
+				if err != nil {
+					t.Fatal(err)
+				}
+				deltaWith, err := term.Delta(g, idV, idW)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := g.RemoveEdge(idV, idW); err != nil {
+					t.Fatal(err)
+				}
+				without, err := term.Stat(g)
+				if err != nil {
+					t.Fatal(err)
+				}
+				deltaWithout, err := term.Delta(g, idV, idW)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if isExist {
+					if err := g.AddEdge(idV, idW, 1.0); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				expected := with - without
+				if math.Abs(deltaWith-expected) > 1e-9 || math.Abs(deltaWithout-expected) > 1e-9 {
+					t.Errorf("expected: %v, actual: %v, %v", expected, deltaWith, deltaWithout)
+				}
+			}
+		}
+	}
+}
+
+func TestNewERGMTerm(t *testing.T) {
+	if _, err := KStarsTerm(0); err != ErrERGMInvalidKStarsK {
+		t.Errorf("expected: %v, actual: %v", ErrERGMInvalidKStarsK, err)
+	}
+	if _, err := GWESPTerm(-1); err != ErrERGMInvalidGWESPDecay {
+		t.Errorf("expected: %v, actual: %v", ErrERGMInvalidGWESPDecay, err)
+	}
+}
+
+func TestNewERGMSampler(t *testing.T) {
+	type input struct {
+		g   *graph.Graph
+		cfg ERGMConfig
+	}
+	type output struct {
+		err error
+	}
+
+	g, err := Path(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := Path(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	terms := []ERGMTerm{EdgesTerm()}
+
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{"success", input{g, ERGMConfig{terms, []float64{-1}, 10, 1}}, output{nil}},
+		{"failure: directed", input{graph.NewDirected(), ERGMConfig{terms, []float64{-1}, 10, 1}}, output{ErrERGMDirected}},
+		{"failure: too few nodes", input{single, ERGMConfig{terms, []float64{-1}, 10, 1}}, output{ErrERGMTooFewNodes}},
+		{"failure: length mismatch", input{g, ERGMConfig{terms, []float64{-1, 1}, 10, 1}}, output{ErrERGMLengthMismatch}},
+		{"failure: invalid burn-in", input{g, ERGMConfig{terms, []float64{-1}, -1, 1}}, output{ErrERGMInvalidBurnIn}},
+		{"failure: invalid thinning", input{g, ERGMConfig{terms, []float64{-1}, 10, 0}}, output{ErrERGMInvalidThinning}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			if _, err := NewERGMSampler(in.g, in.cfg, newTestRand()); err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+			}
+		})
+	}
+}
+
+func TestERGMSampler(t *testing.T) {
+	g, err := Path(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the model of only the edges term is G(n, p) with the log-odds theta
+	p := 0.2
+	terms := newTestERGMTerms(t)
+	thetas := []float64{math.Log(p / (1 - p)), 0, 0, 0}
+
+	s, err := NewERGMSampler(g, ERGMConfig{terms, thetas, 1000, 50}, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samplesNum := 1000
+	sum := 0.0
+	for i := 0; i < samplesNum && s.Next(); i++ {
+		sample := s.Graph()
+		stats := s.Stats()
+		for k, term := range terms {
+			stat, err := term.Stat(sample)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(stat-stats[k]) > 1e-6 {
+				t.Errorf("expected: %v, actual: %v", stat, stats[k])
+			}
+		}
+		testNodesNum(t, 10, sample)
+		testUnitWeights(t, sample)
+		sum += stats[0]
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if avg, expected := sum/float64(samplesNum), 45*p; math.Abs(avg-expected) > 0.5 {
+		t.Errorf("expected: %v, actual: %v", expected, avg)
+	}
+
+	// the initial graph is left as it is
+	testEdgesNum(t, 9, g)
+}
@@ -0,0 +1,112 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrForestFireInvalidN           = errors.New("generate: n must be 0 or more")
+	ErrForestFireInvalidProbability = errors.New("generate: the burning probability must be 0 or more and less than 1")
+)
+
+// ForestFire generates a directed graph of n nodes by the forest fire model.
+// Each new node links to an ambassador chosen uniformly, and then the fire spreads
+// recursively from each linked node to geometrically many of its unvisited heads and tails
+// with means fwdProb / (1 - fwdProb) and bckProb / (1 - bckProb), all of which the new node links to.
+func ForestFire(n int, fwdProb, bckProb float64, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrForestFireInvalidN
+	}
+	if fwdProb < 0 || fwdProb >= 1 || bckProb < 0 || bckProb >= 1 {
+		return nil, ErrForestFireInvalidProbability
+	}
+
+	g := graph.NewDirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	for v := 1; v < n; v++ {
+		idNew := newID(v)
+		idAmbassador := newID(rng.Intn(v))
+
+		visited := map[graph.ID]bool{idNew: true, idAmbassador: true}
+		queue := []graph.ID{idAmbassador}
+		if err := g.AddEdge(idNew, idAmbassador, 1.0); err != nil {
+			return nil, err
+		}
+
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+
+			heads, err := g.GetHeads(id)
+			if err != nil {
+				return nil, err
+			}
+			tails, err := g.GetTails(id)
+			if err != nil {
+				return nil, err
+			}
+
+			burned := append(
+				pickUnvisited(heads, visited, sampleGeometric(fwdProb, rng), rng),
+				pickUnvisited(tails, visited, sampleGeometric(bckProb, rng), rng)...,
+			)
+			for _, idBurned := range burned {
+				if visited[idBurned] {
+					// burned as both a head and a tail
+					continue
+				}
+				visited[idBurned] = true
+				queue = append(queue, idBurned)
+
+				if err := g.AddEdge(idNew, idBurned, 1.0); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// sampleGeometric counts the successes before the first failure of trials
+// succeeding with probability p.
+func sampleGeometric(p float64, rng *rand.Rand) int {
+	cnt := 0
+	for rng.Float64() < p {
+		cnt++
+	}
+	return cnt
+}
+
+// pickUnvisited picks up to num unvisited nodes from the ends uniformly.
+func pickUnvisited(ends map[graph.ID]*graph.Node, visited map[graph.ID]bool, num int, rng *rand.Rand) []graph.ID {
+	if num == 0 {
+		return nil
+	}
+
+	candidates := make([]graph.ID, 0, len(ends))
+	for id := range ends {
+		if !visited[id] {
+			candidates = append(candidates, id)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i] < candidates[j]
+	})
+
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > num {
+		candidates = candidates[:num]
+	}
+
+	return candidates
+}
@@ -0,0 +1,101 @@
+package generate
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestForestFire(t *testing.T) {
+	type input struct {
+		n                int
+		fwdProb, bckProb float64
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{
+			"success",
+			input{300, 0.37, 0.32},
+			output{nil},
+		},
+		{
+			"success: no burning",
+			input{100, 0, 0},
+			output{nil},
+		},
+		{
+			"success: n = 0",
+			input{0, 0.3, 0.3},
+			output{nil},
+		},
+		{
+			"failure: invalid n",
+			input{-1, 0.3, 0.3},
+			output{ErrForestFireInvalidN},
+		},
+		{
+			"failure: invalid forward probability",
+			input{10, 1, 0.3},
+			output{ErrForestFireInvalidProbability},
+		},
+		{
+			"failure: invalid backward probability",
+			input{10, 0.3, -0.1},
+			output{ErrForestFireInvalidProbability},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := ForestFire(in.n, in.fwdProb, in.bckProb, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !g.IsDirected() {
+				t.Errorf("expected: %t, actual: %t", true, g.IsDirected())
+			}
+			testNodesNum(t, in.n, g)
+			testUnitWeights(t, g)
+
+			// every node but the first links to older nodes only
+			for v := 1; v < in.n; v++ {
+				heads, err := g.GetHeads(newID(v))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(heads) == 0 {
+					t.Errorf("expected: > 0, actual: %d", len(heads))
+				}
+				for id := range heads {
+					if w, _ := strconv.Atoi(id.String()); w >= v {
+						t.Errorf("expected: < %d, actual: %d", v, w)
+					}
+				}
+			}
+			if in.fwdProb == 0 && in.bckProb == 0 && in.n > 0 {
+				testEdgesNum(t, in.n-1, g)
+			}
+		})
+	}
+}
+
+func TestForestFire_Densification(t *testing.T) {
+	g, err := ForestFire(1000, 0.37, 0.32, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cnt := countEdges(g); cnt <= 2*999 {
+		t.Errorf("expected: > %d, actual: %d", 2*999, cnt)
+	}
+}
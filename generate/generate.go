@@ -0,0 +1,36 @@
+package generate
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrInvalidProbability = errors.New("generate: the probability must be between 0 and 1")
+)
+
+func isValidProbability(p float64) bool {
+	return p >= 0 && p <= 1
+}
+
+func newID(i int) graph.ID {
+	return graph.ID(strconv.Itoa(i))
+}
+
+func newGraph(isDirected bool) *graph.Graph {
+	if isDirected {
+		return graph.NewDirected()
+	}
+	return graph.NewUndirected()
+}
+
+func addNodes(g *graph.Graph, n int) error {
+	for i := 0; i < n; i++ {
+		if err := g.AddNode(graph.NewNode(strconv.Itoa(i))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
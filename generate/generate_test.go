@@ -0,0 +1,75 @@
+package generate
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+const testSeed = 1
+
+func newTestRand() *rand.Rand {
+	return rand.New(rand.NewSource(testSeed))
+}
+
+func countEdges(g *graph.Graph) int {
+	cnt := 0
+	for _, nodeEdges := range g.GetEdges() {
+		cnt += len(nodeEdges)
+	}
+	if !g.IsDirected() {
+		cnt /= 2
+	}
+	return cnt
+}
+
+func degree(t *testing.T, g *graph.Graph, id graph.ID) int {
+	heads, err := g.GetHeads(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(heads)
+}
+
+func testNodesNum(t *testing.T, expected int, g *graph.Graph) {
+	if len(g.GetNodes()) != expected {
+		t.Errorf("expected: %d, actual: %d", expected, len(g.GetNodes()))
+	}
+}
+
+func testEdgesNum(t *testing.T, expected int, g *graph.Graph) {
+	if countEdges(g) != expected {
+		t.Errorf("expected: %d, actual: %d", expected, countEdges(g))
+	}
+}
+
+func testUnitWeights(t *testing.T, g *graph.Graph) {
+	for _, nodeEdges := range g.GetEdges() {
+		for _, e := range nodeEdges {
+			if e.Weight() != 1.0 {
+				t.Errorf("expected: %f, actual: %f", 1.0, e.Weight())
+				return
+			}
+		}
+	}
+}
+
+func TestNewID(t *testing.T) {
+	if newID(10) != graph.ID("10") {
+		t.Errorf("expected: %q, actual: %q", "10", newID(10))
+	}
+}
+
+func TestAddNodes(t *testing.T) {
+	g := graph.NewUndirected()
+	if err := addNodes(g, 3); err != nil {
+		t.Fatal(err)
+	}
+	testNodesNum(t, 3, g)
+	for i := 0; i < 3; i++ {
+		if _, err := g.GetNode(newID(i)); err != nil {
+			t.Errorf("expected: %v, actual: %v", nil, err)
+		}
+	}
+}
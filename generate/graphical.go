@@ -0,0 +1,168 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrNotGraphical          = errors.New("generate: the degree sequence is not graphical")
+	ErrDegreesLengthMismatch = errors.New("generate: the lengths of the degree sequences must be equal")
+)
+
+// IsGraphical reports whether the degree sequence can be realized by a simple undirected graph,
+// using the Erdős–Gallai theorem.
+func IsGraphical(degrees []int) bool {
+	if validateDegrees(degrees) != nil {
+		return false
+	}
+
+	n := len(degrees)
+	ds := append([]int{}, degrees...)
+	sort.Sort(sort.Reverse(sort.IntSlice(ds)))
+
+	// sums[i] is the sum of ds[0:i]
+	sums := make([]int, n+1)
+	for i, d := range ds {
+		sums[i+1] = sums[i] + d
+	}
+
+	// j is the number of the degrees that are k or more
+	j := n
+	for k := 1; k <= n; k++ {
+		for j > 0 && ds[j-1] < k {
+			j--
+		}
+
+		// sum of min(ds[i], k) over i >= k
+		rest := sums[n] - sums[k]
+		if j > k {
+			rest = (j-k)*k + sums[n] - sums[j]
+		}
+
+		if sums[k] > k*(k-1)+rest {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsDigraphical reports whether the pair of the out-degree and in-degree sequences
+// can be realized by a simple directed graph, using the Fulkerson–Chen–Anstee theorem.
+func IsDigraphical(outdegrees, indegrees []int) bool {
+	if len(outdegrees) != len(indegrees) {
+		return false
+	}
+
+	n := len(outdegrees)
+	type pair struct {
+		out, in int
+	}
+	pairs := make([]pair, n)
+	outSum, inSum := 0, 0
+	for i := 0; i < n; i++ {
+		if outdegrees[i] < 0 || indegrees[i] < 0 {
+			return false
+		}
+		pairs[i] = pair{outdegrees[i], indegrees[i]}
+		outSum += outdegrees[i]
+		inSum += indegrees[i]
+	}
+	if outSum != inSum {
+		return false
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].out != pairs[j].out {
+			return pairs[i].out > pairs[j].out
+		}
+		return pairs[i].in > pairs[j].in
+	})
+
+	lhs := 0
+	for k := 1; k <= n; k++ {
+		lhs += pairs[k-1].out
+
+		rhs := 0
+		for i := 0; i < k; i++ {
+			rhs += minInt(pairs[i].in, k-1)
+		}
+		for i := k; i < n; i++ {
+			rhs += minInt(pairs[i].in, k)
+		}
+
+		if lhs > rhs {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HavelHakimi realizes the degree sequence as a simple undirected graph
+// whose node i has degree degrees[i], by the Havel–Hakimi algorithm.
+// It returns an error wrapping ErrNotGraphical if the sequence is not graphical.
+func HavelHakimi(degrees []int) (*graph.Graph, error) {
+	if err := validateDegrees(degrees); err != nil {
+		return nil, err
+	}
+
+	n := len(degrees)
+
+	g := graph.NewUndirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	rests := append([]int{}, degrees...)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+
+	for n > 0 {
+		sort.SliceStable(order, func(i, j int) bool {
+			return rests[order[i]] > rests[order[j]]
+		})
+
+		v := order[0]
+		if rests[v] == 0 {
+			break
+		}
+
+		k := rests[v]
+		if k > n-1 || rests[order[k]] == 0 {
+			available := 0
+			for _, w := range order[1:] {
+				if rests[w] > 0 {
+					available++
+				}
+			}
+			return nil, fmt.Errorf(
+				"%w: node %d needs %d more neighbors but only %d nodes are available",
+				ErrNotGraphical, v, k, available,
+			)
+		}
+
+		rests[v] = 0
+		for _, w := range order[1 : k+1] {
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+			rests[w]--
+		}
+	}
+
+	return g, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,146 @@
+package generate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsGraphical(t *testing.T) {
+	testCases := []struct {
+		name     string
+		degrees  []int
+		expected bool
+	}{
+		{"success: empty", []int{}, true},
+		{"success: zeros", []int{0, 0, 0}, true},
+		{"success: complete", []int{3, 3, 3, 3}, true},
+		{"success: star", []int{4, 1, 1, 1, 1}, true},
+		{"success: unsorted", []int{1, 2, 3, 2, 2}, true},
+		{"failure: odd sum", []int{1, 1, 1}, false},
+		{"failure: too large degree", []int{3, 1, 1}, false},
+		{"failure: Erdős–Gallai", []int{3, 3, 1, 1}, false},
+		{"failure: negative degree", []int{-1, 1}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := IsGraphical(tc.degrees); actual != tc.expected {
+				t.Errorf("expected: %t, actual: %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsDigraphical(t *testing.T) {
+	testCases := []struct {
+		name       string
+		outdegrees []int
+		indegrees  []int
+		expected   bool
+	}{
+		{"success: empty", []int{}, []int{}, true},
+		{"success: cycle", []int{1, 1, 1}, []int{1, 1, 1}, true},
+		{"success: complete", []int{2, 2, 2}, []int{2, 2, 2}, true},
+		{"success: star", []int{3, 0, 0, 0}, []int{0, 1, 1, 1}, true},
+		{"failure: unequal sums", []int{1, 1}, []int{1, 0}, false},
+		{"failure: loop required", []int{1, 0}, []int{1, 0}, false},
+		{"failure: Fulkerson–Chen–Anstee", []int{2, 2, 0}, []int{0, 2, 2}, false},
+		{"failure: length mismatch", []int{1, 1}, []int{1, 1, 0}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := IsDigraphical(tc.outdegrees, tc.indegrees); actual != tc.expected {
+				t.Errorf("expected: %t, actual: %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsDigraphical_BruteForce(t *testing.T) {
+	n := 3
+
+	// enumerate all the simple directed graphs of n nodes
+	arcs := [][2]int{}
+	for v := 0; v < n; v++ {
+		for w := 0; w < n; w++ {
+			if v != w {
+				arcs = append(arcs, [2]int{v, w})
+			}
+		}
+	}
+	realizable := map[[6]int]bool{}
+	for mask := 0; mask < 1<<uint(len(arcs)); mask++ {
+		key := [6]int{}
+		for i, arc := range arcs {
+			if mask&(1<<uint(i)) != 0 {
+				key[arc[0]]++
+				key[n+arc[1]]++
+			}
+		}
+		realizable[key] = true
+	}
+
+	for code := 0; code < 729; code++ {
+		key := [6]int{}
+		c := code
+		for i := range key {
+			key[i] = c % 3
+			c /= 3
+		}
+		if actual := IsDigraphical(key[:n], key[n:]); actual != realizable[key] {
+			t.Errorf("expected: %t, actual: %t (%v)", realizable[key], actual, key)
+		}
+	}
+}
+
+func TestHavelHakimi(t *testing.T) {
+	degrees := []int{1, 2, 3, 2, 2}
+
+	g, err := HavelHakimi(degrees)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testUnitWeights(t, g)
+	for i, k := range degrees {
+		if actual := degree(t, g, newID(i)); actual != k {
+			t.Errorf("expected: %d, actual: %d", k, actual)
+		}
+	}
+
+	t.Run("success: empty", func(t *testing.T) {
+		g, err := HavelHakimi([]int{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, 0, g)
+	})
+
+	t.Run("failure: not graphical", func(t *testing.T) {
+		if _, err := HavelHakimi([]int{3, 3, 1, 1}); !errors.Is(err, ErrNotGraphical) {
+			t.Errorf("expected: %v, actual: %v", ErrNotGraphical, err)
+		}
+	})
+
+	t.Run("failure: odd sum", func(t *testing.T) {
+		if _, err := HavelHakimi([]int{1, 1, 1}); err != ErrOddDegreeSum {
+			t.Errorf("expected: %v, actual: %v", ErrOddDegreeSum, err)
+		}
+	})
+}
+
+func TestHavelHakimi_ConsistentWithIsGraphical(t *testing.T) {
+	rng := newTestRand()
+	for trial := 0; trial < 500; trial++ {
+		n := rng.Intn(9)
+		degrees := make([]int, n)
+		for i := range degrees {
+			degrees[i] = rng.Intn(n)
+		}
+
+		_, err := HavelHakimi(degrees)
+		if (err == nil) != IsGraphical(degrees) {
+			t.Errorf("inconsistent result for %v: %v", degrees, err)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrGrowthInvalidKernel = errors.New("generate: the attachment kernel must be non-nil and return a finite non-negative value")
+)
+
+// AttachmentNode is the state of an existing node
+// passed to an attachment kernel when a new node arrives.
+type AttachmentNode struct {
+	Degree  int
+	Age     int // number of nodes that have arrived after the node, including the new one
+	Fitness float64
+}
+
+// AttachmentKernel returns the attractiveness of a node,
+// to which the probability that a new node links to the node is proportional.
+type AttachmentKernel func(AttachmentNode) float64
+
+// PowerKernel returns the kernel k^alpha of the degree k,
+// which is sublinear if alpha < 1 and superlinear if alpha > 1.
+func PowerKernel(alpha float64) AttachmentKernel {
+	return func(node AttachmentNode) float64 {
+		return math.Pow(float64(node.Degree), alpha)
+	}
+}
+
+// AttractivenessKernel returns the kernel a + k of the degree k
+// with the initial attractiveness a.
+func AttractivenessKernel(a float64) AttachmentKernel {
+	return func(node AttachmentNode) float64 {
+		return a + float64(node.Degree)
+	}
+}
+
+// FitnessKernel returns the Bianconi–Barabási kernel η * k of the fitness η and the degree k.
+func FitnessKernel() AttachmentKernel {
+	return func(node AttachmentNode) float64 {
+		return node.Fitness * float64(node.Degree)
+	}
+}
+
+// AgingKernel returns the kernel multiplying the given kernel by τ^-nu of the age τ.
+func AgingKernel(kernel AttachmentKernel, nu float64) AttachmentKernel {
+	return func(node AttachmentNode) float64 {
+		return kernel(node) * math.Pow(float64(node.Age), -nu)
+	}
+}
+
+// Growth generates an undirected graph of n nodes by the generalized preferential attachment,
+// growing from a ring of m0 nodes and attaching each new node to m distinct nodes chosen
+// with probability proportional to the attachment kernel.
+// The fitness of each node is sampled by fitness on arrival, or 1 if fitness is nil.
+// If the kernel returns 0 for all the candidates, they are chosen uniformly.
+// As the kernel can depend on the ages, it is evaluated for all the nodes at every arrival,
+// which takes O(n^2 m) time in total.
+func Growth(n, m0, m int, kernel AttachmentKernel, fitness func(*rand.Rand) float64, rng *rand.Rand) (*graph.Graph, error) {
+	if m0 < 1 {
+		return nil, ErrBAInvalidM0
+	}
+	if m < 1 || m > m0 {
+		return nil, ErrBAInvalidM
+	}
+	if n < m0 {
+		return nil, ErrBAInvalidN
+	}
+	if kernel == nil {
+		return nil, ErrGrowthInvalidKernel
+	}
+
+	g := graph.NewUndirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	nodes := make([]AttachmentNode, n)
+	for i := range nodes {
+		nodes[i].Fitness = 1
+		if fitness != nil {
+			nodes[i].Fitness = fitness(rng)
+		}
+	}
+
+	// add default edges
+	for _, pair := range ringPairs(m0) {
+		if err := g.AddEdge(newID(pair[0]), newID(pair[1]), 1.0); err != nil {
+			return nil, err
+		}
+		nodes[pair[0]].Degree++
+		nodes[pair[1]].Degree++
+	}
+
+	weights := make([]float64, n)
+	cums := make([]float64, n)
+
+	for i := m0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			nodes[j].Age = i - j
+			weights[j] = kernel(nodes[j])
+			if weights[j] < 0 || math.IsNaN(weights[j]) || math.IsInf(weights[j], 0) {
+				return nil, ErrGrowthInvalidKernel
+			}
+		}
+
+		// pick target nodes without replacement
+		targets := make([]int, 0, m)
+		for len(targets) < m {
+			sum := 0.0
+			for j := 0; j < i; j++ {
+				sum += weights[j]
+				cums[j] = sum
+			}
+
+			var j int
+			if sum > 0 {
+				x := rng.Float64() * sum
+				j = sort.Search(i, func(j int) bool {
+					return cums[j] > x
+				})
+				for j >= i || weights[j] == 0 {
+					// x has been rounded up to sum
+					j--
+				}
+			} else {
+				j = pickUnpicked(i, targets, rng)
+			}
+
+			targets = append(targets, j)
+			weights[j] = 0
+		}
+
+		// add edges
+		for _, j := range targets {
+			if err := g.AddEdge(newID(i), newID(j), 1.0); err != nil {
+				return nil, err
+			}
+			nodes[i].Degree++
+			nodes[j].Degree++
+		}
+	}
+
+	return g, nil
+}
+
+// pickUnpicked picks one of the nodes 0 to n-1 that are not picked uniformly.
+func pickUnpicked(n int, picked []int, rng *rand.Rand) int {
+	isPicked := make(map[int]bool, len(picked))
+	for _, j := range picked {
+		isPicked[j] = true
+	}
+
+	target := rng.Intn(n - len(picked))
+	for j := 0; j < n; j++ {
+		if isPicked[j] {
+			continue
+		}
+		if target == 0 {
+			return j
+		}
+		target--
+	}
+
+	return -1
+}
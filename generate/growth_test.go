@@ -0,0 +1,88 @@
+package generate
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestKernels(t *testing.T) {
+	node := AttachmentNode{Degree: 4, Age: 2, Fitness: 0.5}
+
+	testCases := []struct {
+		name     string
+		kernel   AttachmentKernel
+		expected float64
+	}{
+		{"power", PowerKernel(0.5), 2},
+		{"attractiveness", AttractivenessKernel(1.5), 5.5},
+		{"fitness", FitnessKernel(), 2},
+		{"aging", AgingKernel(PowerKernel(1), 1), 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.kernel(node); actual != tc.expected {
+				t.Errorf("expected: %f, actual: %f", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGrowth(t *testing.T) {
+	n, m0, m := 300, 3, 2
+
+	kernels := map[string]AttachmentKernel{
+		"linear":         PowerKernel(1),
+		"sublinear":      PowerKernel(0.5),
+		"superlinear":    PowerKernel(1.5),
+		"attractiveness": AttractivenessKernel(2),
+		"fitness":        FitnessKernel(),
+		"aging":          AgingKernel(PowerKernel(1), 1),
+		"zero":           func(AttachmentNode) float64 { return 0 },
+	}
+	fitness := func(rng *rand.Rand) float64 {
+		return rng.Float64()
+	}
+
+	for name, kernel := range kernels {
+		t.Run(name, func(t *testing.T) {
+			g, err := Growth(n, m0, m, kernel, fitness, newTestRand())
+			if err != nil {
+				t.Fatal(err)
+			}
+			testNodesNum(t, n, g)
+			testEdgesNum(t, m0+(n-m0)*m, g)
+			testUnitWeights(t, g)
+		})
+	}
+
+	t.Run("success: superlinear condensation", func(t *testing.T) {
+		g, err := Growth(n, m0, 1, PowerKernel(3), nil, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// a single node gets almost all the links
+		if kMax := maxDegree(t, g, true); kMax < n/2 {
+			t.Errorf("expected: >= %d, actual: %d", n/2, kMax)
+		}
+	})
+
+	t.Run("failure: invalid kernel", func(t *testing.T) {
+		kernel := func(AttachmentNode) float64 { return -1 }
+		if _, err := Growth(n, m0, m, kernel, nil, newTestRand()); err != ErrGrowthInvalidKernel {
+			t.Errorf("expected: %v, actual: %v", ErrGrowthInvalidKernel, err)
+		}
+	})
+
+	t.Run("failure: nil kernel", func(t *testing.T) {
+		if _, err := Growth(n, m0, m, nil, nil, newTestRand()); err != ErrGrowthInvalidKernel {
+			t.Errorf("expected: %v, actual: %v", ErrGrowthInvalidKernel, err)
+		}
+	})
+
+	t.Run("failure: invalid m", func(t *testing.T) {
+		if _, err := Growth(n, m0, m0+1, PowerKernel(1), nil, newTestRand()); err != ErrBAInvalidM {
+			t.Errorf("expected: %v, actual: %v", ErrBAInvalidM, err)
+		}
+	})
+}
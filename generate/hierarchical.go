@@ -0,0 +1,125 @@
+package generate
+
+import (
+	"errors"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrHierarchicalInvalidGenerations = errors.New("generate: the number of generations must be 0 or more")
+)
+
+// RavaszBarabasi generates the undirected hierarchical network of Ravasz and Barabási
+// of 5^(t+1) nodes after t generations.
+// Generation 0 is a complete graph of the center 0 and the peripheral nodes 1 to 4,
+// and each generation adds 4 copies of the current graph numbered after it,
+// connecting the peripheral nodes of the copies to node 0,
+// which become the peripheral nodes of the next generation.
+func RavaszBarabasi(t int) (*graph.Graph, error) {
+	if t < 0 {
+		return nil, ErrHierarchicalInvalidGenerations
+	}
+
+	nodesNum := int64(5)
+	for i := 0; i < t; i++ {
+		nodesNum *= 5
+		if nodesNum > maxTopologyNodesNum {
+			return nil, ErrTopologyTooLarge
+		}
+	}
+
+	n := 5
+	pairs := completePairs(0, n)
+	peripherals := []int{1, 2, 3, 4}
+	for i := 0; i < t; i++ {
+		pairsNum := len(pairs)
+		nextPeripherals := make([]int, 0, 4*len(peripherals))
+		for c := 1; c <= 4; c++ {
+			offset := c * n
+			for _, pair := range pairs[:pairsNum] {
+				pairs = append(pairs, [2]int{pair[0] + offset, pair[1] + offset})
+			}
+			for _, v := range peripherals {
+				pairs = append(pairs, [2]int{0, v + offset})
+				nextPeripherals = append(nextPeripherals, v+offset)
+			}
+		}
+		n *= 5
+		peripherals = nextPeripherals
+	}
+
+	return newGraphFromPairs(n, pairs)
+}
+
+// Apollonian generates the undirected deterministic Apollonian network
+// of 3 + (3^t - 1) / 2 nodes after t generations.
+// Generation 0 is a triangle of nodes 0 to 2,
+// and each generation adds a node into each of the triangles added in the previous generation,
+// connecting it to the 3 nodes of the triangle.
+func Apollonian(t int) (*graph.Graph, error) {
+	if t < 0 {
+		return nil, ErrHierarchicalInvalidGenerations
+	}
+
+	nodesNum, width := int64(3), int64(1)
+	for i := 0; i < t; i++ {
+		nodesNum += width
+		if nodesNum > maxTopologyNodesNum {
+			return nil, ErrTopologyTooLarge
+		}
+		width *= 3
+	}
+
+	n := 3
+	pairs := completePairs(0, n)
+	triangles := [][3]int{{0, 1, 2}}
+	for i := 0; i < t; i++ {
+		nextTriangles := make([][3]int, 0, 3*len(triangles))
+		for _, tri := range triangles {
+			v := n
+			n++
+			pairs = append(pairs, [2]int{tri[0], v}, [2]int{tri[1], v}, [2]int{tri[2], v})
+			nextTriangles = append(nextTriangles,
+				[3]int{tri[0], tri[1], v},
+				[3]int{tri[1], tri[2], v},
+				[3]int{tri[0], tri[2], v},
+			)
+		}
+		triangles = nextTriangles
+	}
+
+	return newGraphFromPairs(n, pairs)
+}
+
+// DorogovtsevGoltsevMendes generates the undirected pseudofractal graph of Dorogovtsev, Goltsev and Mendes
+// of (3^t + 3) / 2 nodes and 3^t edges after t generations.
+// Generation 0 is an edge between nodes 0 and 1,
+// and each generation adds a node for each of the current edges, connecting it to both ends.
+func DorogovtsevGoltsevMendes(t int) (*graph.Graph, error) {
+	if t < 0 {
+		return nil, ErrHierarchicalInvalidGenerations
+	}
+
+	nodesNum, width := int64(2), int64(1)
+	for i := 0; i < t; i++ {
+		nodesNum += width
+		if nodesNum > maxTopologyNodesNum {
+			return nil, ErrTopologyTooLarge
+		}
+		width *= 3
+	}
+
+	n := 2
+	pairs := [][2]int{{0, 1}}
+	for i := 0; i < t; i++ {
+		pairsNum := len(pairs)
+		for _, pair := range pairs[:pairsNum] {
+			v := n
+			n++
+			pairs = append(pairs, [2]int{pair[0], v}, [2]int{pair[1], v})
+		}
+	}
+
+	return newGraphFromPairs(n, pairs)
+}
@@ -0,0 +1,126 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func pow(x, y int) int {
+	z := 1
+	for i := 0; i < y; i++ {
+		z *= x
+	}
+	return z
+}
+
+func testDegreeDistribution(t *testing.T, expected map[int]int, g *graph.Graph) {
+	t.Helper()
+
+	dist := g.GetIndegreeDistribution()
+	ks := dist.GetDegrees()
+	if len(ks) != len(expected) {
+		t.Errorf("expected: %d, actual: %d", len(expected), len(ks))
+	}
+	for k, num := range expected {
+		if actual := dist.GetNum(k); actual != num {
+			t.Errorf("k = %d, expected: %d, actual: %d", k, num, actual)
+		}
+	}
+}
+
+func TestRavaszBarabasi(t *testing.T) {
+	for gen := 0; gen <= 3; gen++ {
+		g, err := RavaszBarabasi(gen)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		edgesNum, peripheralsNum := 10, 4
+		for i := 0; i < gen; i++ {
+			edgesNum = 5*edgesNum + 4*peripheralsNum
+			peripheralsNum *= 4
+		}
+		testNodesNum(t, pow(5, gen+1), g)
+		testEdgesNum(t, edgesNum, g)
+		testUnitWeights(t, g)
+
+		// a center with j leading original blocks has degree (4^(j+2) - 4) / 3,
+		// a peripheral node with j leading copied blocks has degree 4 + j
+		expected := map[int]int{}
+		for j := 0; j <= gen; j++ {
+			// the number of the choices of the remaining blocks after the j leading ones
+			rest := 1
+			if j < gen {
+				rest = pow(5, gen-j-1)
+			}
+			expected[(pow(4, j+2)-4)/3] += pow(4, minInt(1, gen-j)) * rest
+			expected[4+j] += 4 * pow(4, j) * rest
+		}
+		testDegreeDistribution(t, expected, g)
+	}
+
+	if _, err := RavaszBarabasi(-1); err != ErrHierarchicalInvalidGenerations {
+		t.Errorf("expected: %v, actual: %v", ErrHierarchicalInvalidGenerations, err)
+	}
+	if _, err := RavaszBarabasi(20); err != ErrTopologyTooLarge {
+		t.Errorf("expected: %v, actual: %v", ErrTopologyTooLarge, err)
+	}
+}
+
+func TestApollonian(t *testing.T) {
+	for gen := 0; gen <= 6; gen++ {
+		g, err := Apollonian(gen)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n := 3 + (pow(3, gen)-1)/2
+		testNodesNum(t, n, g)
+		testEdgesNum(t, 3+3*(n-3), g)
+		testUnitWeights(t, g)
+
+		// the initial nodes have degree 2^t + 1,
+		// the 3^(s-1) nodes added in generation s have degree 3 * 2^(t-s)
+		expected := map[int]int{pow(2, gen) + 1: 3}
+		for s := 1; s <= gen; s++ {
+			expected[3*pow(2, gen-s)] += pow(3, s-1)
+		}
+		testDegreeDistribution(t, expected, g)
+	}
+
+	if _, err := Apollonian(-1); err != ErrHierarchicalInvalidGenerations {
+		t.Errorf("expected: %v, actual: %v", ErrHierarchicalInvalidGenerations, err)
+	}
+	if _, err := Apollonian(40); err != ErrTopologyTooLarge {
+		t.Errorf("expected: %v, actual: %v", ErrTopologyTooLarge, err)
+	}
+}
+
+func TestDorogovtsevGoltsevMendes(t *testing.T) {
+	for gen := 0; gen <= 6; gen++ {
+		g, err := DorogovtsevGoltsevMendes(gen)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testNodesNum(t, (pow(3, gen)+3)/2, g)
+		testEdgesNum(t, pow(3, gen), g)
+		testUnitWeights(t, g)
+
+		// the initial nodes have degree 2^t,
+		// the 3^(s-1) nodes added in generation s have degree 2^(t-s+1)
+		expected := map[int]int{pow(2, gen): 2}
+		for s := 1; s <= gen; s++ {
+			expected[pow(2, gen-s+1)] += pow(3, s-1)
+		}
+		testDegreeDistribution(t, expected, g)
+	}
+
+	if _, err := DorogovtsevGoltsevMendes(-1); err != ErrHierarchicalInvalidGenerations {
+		t.Errorf("expected: %v, actual: %v", ErrHierarchicalInvalidGenerations, err)
+	}
+	if _, err := DorogovtsevGoltsevMendes(40); err != ErrTopologyTooLarge {
+		t.Errorf("expected: %v, actual: %v", ErrTopologyTooLarge, err)
+	}
+}
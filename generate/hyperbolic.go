@@ -0,0 +1,203 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrHyperbolicInvalidN           = errors.New("generate: n must be 1 or more")
+	ErrHyperbolicInvalidAvgDegree   = errors.New("generate: the average degree must be more than 0")
+	ErrHyperbolicInvalidGamma       = errors.New("generate: gamma must be more than 2")
+	ErrHyperbolicInvalidTemperature = errors.New("generate: the temperature must be 0 or more and less than 1")
+	ErrHyperbolicTooLargeAvgDegree  = errors.New("generate: the average degree is too large for n to give a positive radius")
+)
+
+// PolarCoordinate is a point in the hyperbolic disk.
+type PolarCoordinate struct {
+	R     float64
+	Theta float64
+}
+
+// Hyperbolic generates an undirected graph of n nodes by the hyperbolic random graph model,
+// whose degrees follow a power law with exponent gamma.
+// The nodes are placed in the hyperbolic disk of radius R, chosen for the average degree avgDegree,
+// and each pair of nodes at hyperbolic distance x is connected if x <= R when the temperature is 0,
+// or with probability 1 / (1 + exp((x - R) / (2 * temperature))) otherwise.
+// It also returns the polar coordinate of each node, and each node holds its Cartesian position.
+// It runs in sub-quadratic time by partitioning the disk into bands,
+// scanning the nodes of each band in order of their angular distance and skipping over them geometrically.
+func Hyperbolic(n int, avgDegree, gamma, temperature float64, rng *rand.Rand) (*graph.Graph, map[graph.ID]PolarCoordinate, error) {
+	if n < 1 {
+		return nil, nil, ErrHyperbolicInvalidN
+	}
+	if avgDegree <= 0 {
+		return nil, nil, ErrHyperbolicInvalidAvgDegree
+	}
+	if gamma <= 2 {
+		return nil, nil, ErrHyperbolicInvalidGamma
+	}
+	if temperature < 0 || temperature >= 1 {
+		return nil, nil, ErrHyperbolicInvalidTemperature
+	}
+
+	alpha := (gamma - 1) / 2
+	radius := hyperbolicRadius(n, avgDegree, alpha, temperature)
+	if !(radius > 0) {
+		return nil, nil, ErrHyperbolicTooLargeAvgDegree
+	}
+
+	prob := func(x float64) float64 {
+		if temperature == 0 {
+			if x <= radius {
+				return 1
+			}
+			return 0
+		}
+		return 1 / (1 + math.Exp((x-radius)/(2*temperature)))
+	}
+
+	// place nodes
+	g := graph.NewUndirected()
+	coords := make([]PolarCoordinate, n)
+	coordMap := make(map[graph.ID]PolarCoordinate, n)
+	for v := range coords {
+		r := math.Acosh(1+(math.Cosh(alpha*radius)-1)*rng.Float64()) / alpha
+		theta := 2 * math.Pi * rng.Float64()
+		coords[v] = PolarCoordinate{r, theta}
+		coordMap[newID(v)] = coords[v]
+
+		node := graph.NewNode(newID(v).String())
+		node.SetPosition([]float64{r * math.Cos(theta), r * math.Sin(theta)})
+		if err := g.AddNode(node); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// partition the disk into bands, in each of which the nodes are sorted by their angles
+	bandsNum := int(math.Ceil(math.Log(float64(n)))) + 1
+	bandWidth := radius / float64(bandsNum)
+	bands := make([][]int, bandsNum)
+	for v, coord := range coords {
+		b := minInt(int(coord.R/bandWidth), bandsNum-1)
+		bands[b] = append(bands[b], v)
+	}
+	for _, band := range bands {
+		sort.Slice(band, func(i, j int) bool {
+			return coords[band[i]].Theta < coords[band[j]].Theta
+		})
+	}
+
+	// isOuter reports whether w is farther from the center than v,
+	// so that each pair is considered only from the inner node
+	isOuter := func(v, w int) bool {
+		if coords[v].R != coords[w].R {
+			return coords[v].R < coords[w].R
+		}
+		return v < w
+	}
+
+	for v, coord := range coords {
+		for b, band := range bands {
+			rMin := math.Max(float64(b)*bandWidth, coord.R)
+			rMax := float64(b+1) * bandWidth
+			if b == bandsNum-1 {
+				rMax = math.Inf(1)
+			}
+			if len(band) == 0 || rMax < coord.R {
+				continue
+			}
+
+			// the index of the first node whose angle is theta or more
+			start := sort.Search(len(band), func(i int) bool {
+				return coords[band[i]].Theta >= coord.Theta
+			})
+
+			for _, isClockwise := range []bool{true, false} {
+				// the i-th node in the scan
+				nodeAt := func(i int) int {
+					if isClockwise {
+						return band[(start+i)%len(band)]
+					}
+					return band[((start-1-i)%len(band)+len(band))%len(band)]
+				}
+				// the upper bound of the probabilities of the nodes from the i-th in the scan
+				boundAt := func(i int) float64 {
+					delta := angularDistance(coord.Theta, coords[nodeAt(i)].Theta)
+					return prob(minHyperbolicDistance(coord.R, rMin, rMax, delta))
+				}
+
+				for i, p := 0, 1.0; i < len(band) && p > 0; i++ {
+					if p < 1 {
+						skip := math.Floor(math.Log(1-rng.Float64()) / math.Log1p(-p))
+						if !(skip < float64(len(band)-i)) {
+							break
+						}
+						i += int(skip)
+					}
+
+					w := nodeAt(i)
+					delta := math.Mod(coords[w].Theta-coord.Theta+2*math.Pi, 2*math.Pi)
+					if isClockwise == (delta >= math.Pi) {
+						// the node is in the half scanned in the other direction
+						break
+					}
+
+					q := prob(hyperbolicDistance(coord, coords[w]))
+					if w != v && isOuter(v, w) && rng.Float64()*p < q {
+						if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+							return nil, nil, err
+						}
+					}
+					p = boundAt(i)
+				}
+			}
+		}
+	}
+
+	return g, coordMap, nil
+}
+
+// hyperbolicRadius calculates the radius of the disk for the average degree
+// by the asymptotic formula avgDegree = (2 / π) ξ^2 n exp(-R / 2) (πT / sin(πT)),
+// where ξ = α / (α - 1/2).
+func hyperbolicRadius(n int, avgDegree, alpha, temperature float64) float64 {
+	xi := alpha / (alpha - 0.5)
+	factor := 1.0
+	if temperature > 0 {
+		factor = math.Pi * temperature / math.Sin(math.Pi*temperature)
+	}
+	return 2 * math.Log(2*xi*xi*float64(n)*factor/(math.Pi*avgDegree))
+}
+
+func hyperbolicDistance(c1, c2 PolarCoordinate) float64 {
+	return hyperbolicDistanceByAngle(c1.R, c2.R, angularDistance(c1.Theta, c2.Theta))
+}
+
+// hyperbolicDistanceByAngle calculates the distance between the points at radii r1 and r2
+// with the angular distance delta, by cosh x = cosh(r1 - r2) + 2 sinh r1 sinh r2 sin^2(delta / 2),
+// which avoids the cancellation for small delta.
+func hyperbolicDistanceByAngle(r1, r2, delta float64) float64 {
+	s := math.Sin(delta / 2)
+	return math.Acosh(math.Cosh(r1-r2) + 2*math.Sinh(r1)*math.Sinh(r2)*s*s)
+}
+
+// minHyperbolicDistance calculates the minimum distance from the point at radius r
+// to the points at radii in [rMin, rMax] with the angular distance delta.
+func minHyperbolicDistance(r, rMin, rMax, delta float64) float64 {
+	// the distance is minimized at tanh r' = tanh r cos delta
+	rBest := 0.0
+	if c := math.Tanh(r) * math.Cos(delta); c > 0 {
+		rBest = math.Atanh(c)
+	}
+	return hyperbolicDistanceByAngle(r, math.Min(math.Max(rBest, rMin), rMax), delta)
+}
+
+func angularDistance(theta1, theta2 float64) float64 {
+	delta := math.Abs(theta1 - theta2)
+	return math.Min(delta, 2*math.Pi-delta)
+}
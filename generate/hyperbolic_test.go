@@ -0,0 +1,123 @@
+package generate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHyperbolic(t *testing.T) {
+	n, avgDegree, gamma := 2000, 10.0, 2.5
+
+	for _, temperature := range []float64{0, 0.05, 0.1, 0.5} {
+		g, coords, err := Hyperbolic(n, avgDegree, gamma, temperature, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, n, g)
+		testUnitWeights(t, g)
+		if len(coords) != n {
+			t.Errorf("expected: %d, actual: %d", n, len(coords))
+		}
+
+		// the average degree is approximated asymptotically
+		if actual := 2 * float64(countEdges(g)) / float64(n); math.Abs(actual-avgDegree) > 0.3*avgDegree {
+			t.Errorf("expected: %f, actual: %f", avgDegree, actual)
+		}
+
+		for id, n := range g.GetNodes() {
+			coord := coords[id]
+			pos := n.Position()
+			if math.Abs(pos[0]-coord.R*math.Cos(coord.Theta)) > 1e-9 || math.Abs(pos[1]-coord.R*math.Sin(coord.Theta)) > 1e-9 {
+				t.Errorf("expected: (%f, %f), actual: %v", coord.R, coord.Theta, pos)
+				break
+			}
+		}
+	}
+
+	t.Run("failure: invalid gamma", func(t *testing.T) {
+		if _, _, err := Hyperbolic(n, avgDegree, 2, 0, newTestRand()); err != ErrHyperbolicInvalidGamma {
+			t.Errorf("expected: %v, actual: %v", ErrHyperbolicInvalidGamma, err)
+		}
+	})
+
+	t.Run("failure: invalid temperature", func(t *testing.T) {
+		if _, _, err := Hyperbolic(n, avgDegree, gamma, 1, newTestRand()); err != ErrHyperbolicInvalidTemperature {
+			t.Errorf("expected: %v, actual: %v", ErrHyperbolicInvalidTemperature, err)
+		}
+	})
+
+	t.Run("failure: invalid average degree", func(t *testing.T) {
+		if _, _, err := Hyperbolic(n, 0, gamma, 0, newTestRand()); err != ErrHyperbolicInvalidAvgDegree {
+			t.Errorf("expected: %v, actual: %v", ErrHyperbolicInvalidAvgDegree, err)
+		}
+	})
+
+	t.Run("failure: too large average degree", func(t *testing.T) {
+		if _, _, err := Hyperbolic(10, 20, 10, 0, newTestRand()); err != ErrHyperbolicTooLargeAvgDegree {
+			t.Errorf("expected: %v, actual: %v", ErrHyperbolicTooLargeAvgDegree, err)
+		}
+	})
+}
+
+func TestHyperbolic_Threshold(t *testing.T) {
+	n, avgDegree, gamma := 500, 8.0, 2.7
+
+	g, coords, err := Hyperbolic(n, avgDegree, gamma, 0, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	radius := hyperbolicRadius(n, avgDegree, (gamma-1)/2, 0)
+
+	// compare with the brute force
+	expected := 0
+	for v := 0; v < n; v++ {
+		for w := v + 1; w < n; w++ {
+			isNear := hyperbolicDistance(coords[newID(v)], coords[newID(w)]) <= radius
+			if isNear {
+				expected++
+			}
+			if ok, _ := isAdjacent(g, newID(v), newID(w)); ok != isNear {
+				t.Errorf("expected: %t, actual: %t", isNear, ok)
+			}
+		}
+	}
+	testEdgesNum(t, expected, g)
+}
+
+func TestHyperbolic_Temperature(t *testing.T) {
+	n, avgDegree, gamma := 300, 8.0, 2.7
+
+	for _, temperature := range []float64{0.1, 0.6} {
+		// the number of edges should be within 5 standard deviations
+		// from the expected one given the coordinates
+		g, coords, err := Hyperbolic(n, avgDegree, gamma, temperature, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		radius := hyperbolicRadius(n, avgDegree, (gamma-1)/2, temperature)
+
+		mean, variance := 0.0, 0.0
+		for v := 0; v < n; v++ {
+			for w := v + 1; w < n; w++ {
+				x := hyperbolicDistance(coords[newID(v)], coords[newID(w)])
+				p := 1 / (1 + math.Exp((x-radius)/(2*temperature)))
+				mean += p
+				variance += p * (1 - p)
+			}
+		}
+		if diff := math.Abs(float64(countEdges(g)) - mean); diff > 5*math.Sqrt(variance) {
+			t.Errorf("expected: %f +/- %f, actual: %d", mean, 5*math.Sqrt(variance), countEdges(g))
+		}
+	}
+}
+
+func TestMinHyperbolicDistance(t *testing.T) {
+	r, delta := 3.0, 0.5
+	expected := math.Inf(1)
+	for r2 := 1.0; r2 <= 4.0; r2 += 0.001 {
+		expected = math.Min(expected, hyperbolicDistanceByAngle(r, r2, delta))
+	}
+	if actual := minHyperbolicDistance(r, 1, 4, delta); math.Abs(actual-expected) > 1e-6 {
+		t.Errorf("expected: %f, actual: %f", expected, actual)
+	}
+}
@@ -0,0 +1,193 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+const kleinbergMaxRejectionsNum = 100
+
+var (
+	ErrKleinbergInvalidL = errors.New("generate: l must be 1 or more")
+	ErrKleinbergInvalidP = errors.New("generate: p must be 0 or more")
+	ErrKleinbergInvalidQ = errors.New("generate: q must be 0 or more and the number of the nodes without local links or less")
+	ErrKleinbergInvalidR = errors.New("generate: r must be 0 or more")
+)
+
+// Kleinberg generates a directed graph by Kleinberg's navigable small-world model
+// on an l x l lattice. Each node links to every node within lattice (Manhattan) distance p,
+// and has q long-range links to distinct other nodes, each of which is chosen
+// with probability proportional to d^-r of the lattice distance d.
+// The node at (x, y) is numbered x * l + y and holds the coordinates as its position,
+// so that greedy routing can be run on the graph.
+// The long-range links are sampled by rejection, falling back to weighted sampling
+// over all the candidates after many consecutive rejections.
+func Kleinberg(l, p, q int, r float64, rng *rand.Rand) (*graph.Graph, error) {
+	if l < 1 {
+		return nil, ErrKleinbergInvalidL
+	}
+	if p < 0 {
+		return nil, ErrKleinbergInvalidP
+	}
+	if q < 0 {
+		return nil, ErrKleinbergInvalidQ
+	}
+	if r < 0 {
+		return nil, ErrKleinbergInvalidR
+	}
+
+	n := l * l
+
+	g := graph.NewDirected()
+	for v := 0; v < n; v++ {
+		node := graph.NewNode(newID(v).String())
+		node.SetPosition([]float64{float64(v / l), float64(v % l)})
+		if err := g.AddNode(node); err != nil {
+			return nil, err
+		}
+	}
+
+	// add local links
+	for v := 0; v < n; v++ {
+		x, y := v/l, v%l
+		for dx := -p; dx <= p; dx++ {
+			for dy := -(p - absInt(dx)); dy <= p-absInt(dx); dy++ {
+				xw, yw := x+dx, y+dy
+				if (dx == 0 && dy == 0) || xw < 0 || xw >= l || yw < 0 || yw >= l {
+					continue
+				}
+				if err := g.AddEdge(newID(v), newID(xw*l+yw), 1.0); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if q == 0 || n == 1 {
+		return g, nil
+	}
+
+	// the weight of the distance d is the number of the lattice points at distance d times d^-r
+	maxDistance := 2 * (l - 1)
+	cums := make([]float64, maxDistance)
+	sum := 0.0
+	for d := 1; d <= maxDistance; d++ {
+		sum += 4 * float64(d) * math.Pow(float64(d), -r)
+		cums[d-1] = sum
+	}
+
+	// add long-range links
+	for v := 0; v < n; v++ {
+		heads, err := g.GetHeads(newID(v))
+		if err != nil {
+			return nil, err
+		}
+		if q > n-1-len(heads) {
+			return nil, ErrKleinbergInvalidQ
+		}
+
+		x, y := v/l, v%l
+		linked := make(map[int]bool, q)
+		for rejected := 0; len(linked) < q; {
+			if rejected >= kleinbergMaxRejectionsNum {
+				// the number of the consecutive rejections does not depend on the accepted node,
+				// so the rest can be sampled in another way
+				for _, w := range pickByDistance(l, v, r, q-len(linked), heads, rng) {
+					linked[w] = true
+					if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+						return nil, err
+					}
+				}
+				break
+			}
+
+			// pick a point uniformly at a distance on the infinite lattice,
+			// rejecting the points outside the lattice
+			d := 1 + sort.SearchFloat64s(cums, rng.Float64()*sum)
+			if d > maxDistance {
+				d = maxDistance
+			}
+			i := rng.Intn(4 * d)
+			dx, dy := diamondPoint(d, i)
+			xw, yw := x+dx, y+dy
+			if xw < 0 || xw >= l || yw < 0 || yw >= l {
+				rejected++
+				continue
+			}
+
+			w := xw*l + yw
+			if _, ok := heads[newID(w)]; ok || linked[w] {
+				rejected++
+				continue
+			}
+			rejected = 0
+			linked[w] = true
+
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// pickByDistance samples num distinct nodes other than v and the heads
+// with probability proportional to d^-r of the lattice distance d, one after another.
+// It picks the num smallest keys log(-log u) + r log d of the Efraimidis–Spirakis sampling,
+// which do not underflow for large r.
+func pickByDistance(l, v int, r float64, num int, heads map[graph.ID]*graph.Node, rng *rand.Rand) []int {
+	type candidate struct {
+		w   int
+		key float64
+	}
+
+	x, y := v/l, v%l
+	candidates := []candidate{}
+	for w := 0; w < l*l; w++ {
+		if w == v {
+			continue
+		}
+		if _, ok := heads[newID(w)]; ok {
+			continue
+		}
+		d := absInt(w/l-x) + absInt(w%l-y)
+		key := math.Log(-math.Log(1-rng.Float64())) + r*math.Log(float64(d))
+		candidates = append(candidates, candidate{w, key})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].key < candidates[j].key
+	})
+
+	ws := make([]int, 0, num)
+	for _, c := range candidates[:minInt(num, len(candidates))] {
+		ws = append(ws, c.w)
+	}
+	return ws
+}
+
+// diamondPoint returns the i-th of the 4d lattice points at distance d from the origin.
+func diamondPoint(d, i int) (int, int) {
+	side, j := i/d, i%d
+	switch side {
+	case 0:
+		return d - j, j
+	case 1:
+		return -j, d - j
+	case 2:
+		return -d + j, -j
+	default:
+		return j, -d + j
+	}
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
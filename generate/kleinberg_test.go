@@ -0,0 +1,125 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func calcAverageLinkDistance(g *graph.Graph) float64 {
+	sum, cnt := 0.0, 0
+	for _, nodeEdges := range g.GetEdges() {
+		for _, e := range nodeEdges {
+			pos1, pos2 := e.Tail().Position(), e.Head().Position()
+			d := absInt(int(pos1[0]-pos2[0])) + absInt(int(pos1[1]-pos2[1]))
+			if d > 1 {
+				sum += float64(d)
+				cnt++
+			}
+		}
+	}
+	return sum / float64(cnt)
+}
+
+func TestKleinberg(t *testing.T) {
+	l, p, q := 20, 1, 2
+
+	g, err := Kleinberg(l, p, q, 2, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.IsDirected() {
+		t.Errorf("expected: %t, actual: %t", true, g.IsDirected())
+	}
+	testNodesNum(t, l*l, g)
+	testUnitWeights(t, g)
+
+	// 2 * 2 * l * (l - 1) local links and q long-range links per node
+	testEdgesNum(t, 4*l*(l-1)+q*l*l, g)
+
+	// node 21 is at (1, 1) and linked to its 4 neighbors
+	n, err := g.GetNode(newID(21))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos := n.Position(); pos[0] != 1 || pos[1] != 1 {
+		t.Errorf("expected: %v, actual: %v", []float64{1, 1}, pos)
+	}
+	for _, id := range []graph.ID{"1", "20", "22", "41"} {
+		if ok, _ := isAdjacent(g, newID(21), id); !ok {
+			t.Errorf("expected: %t, actual: %t", true, ok)
+		}
+	}
+
+	t.Run("success: clustering exponent", func(t *testing.T) {
+		gUniform, err := Kleinberg(l, p, q, 0, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		dLocal, dUniform := calcAverageLinkDistance(g), calcAverageLinkDistance(gUniform)
+		if dLocal >= dUniform {
+			t.Errorf("expected: < %f, actual: %f", dUniform, dLocal)
+		}
+	})
+
+	t.Run("success: wider local links", func(t *testing.T) {
+		g, err := Kleinberg(5, 2, 0, 2, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k := degree(t, g, newID(12)); k != 12 {
+			t.Errorf("expected: %d, actual: %d", 12, k)
+		}
+	})
+
+	t.Run("success: almost all the nodes linked", func(t *testing.T) {
+		q := l*l - 5
+
+		g, err := Kleinberg(l, 1, q, 4, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the corner nodes have only 2 local links
+		if k := degree(t, g, newID(0)); k != 2+q {
+			t.Errorf("expected: %d, actual: %d", 2+q, k)
+		}
+		testEdgesNum(t, 4*l*(l-1)+q*l*l, g)
+	})
+
+	t.Run("success: large r", func(t *testing.T) {
+		g, err := Kleinberg(l, p, q, 1000, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testEdgesNum(t, 4*l*(l-1)+q*l*l, g)
+	})
+
+	t.Run("failure: too many long-range links", func(t *testing.T) {
+		if _, err := Kleinberg(2, 1, 2, 2, newTestRand()); err != ErrKleinbergInvalidQ {
+			t.Errorf("expected: %v, actual: %v", ErrKleinbergInvalidQ, err)
+		}
+	})
+
+	t.Run("failure: invalid r", func(t *testing.T) {
+		if _, err := Kleinberg(l, p, q, -1, newTestRand()); err != ErrKleinbergInvalidR {
+			t.Errorf("expected: %v, actual: %v", ErrKleinbergInvalidR, err)
+		}
+	})
+}
+
+func TestDiamondPoint(t *testing.T) {
+	for d := 1; d <= 5; d++ {
+		seen := map[[2]int]bool{}
+		for i := 0; i < 4*d; i++ {
+			dx, dy := diamondPoint(d, i)
+			if absInt(dx)+absInt(dy) != d {
+				t.Errorf("expected: %d, actual: %d", d, absInt(dx)+absInt(dy))
+			}
+			seen[[2]int{dx, dy}] = true
+		}
+		if len(seen) != 4*d {
+			t.Errorf("expected: %d, actual: %d", 4*d, len(seen))
+		}
+	}
+}
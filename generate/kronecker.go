@@ -0,0 +1,187 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrKroneckerInvalidInitiator = errors.New("generate: the initiator must be a non-empty square matrix of probabilities")
+	ErrKroneckerInvalidK         = errors.New("generate: k must be 1 or more")
+	ErrKroneckerTooLarge         = errors.New("generate: the number of nodes is too large")
+	ErrKroneckerTooManyEdges     = errors.New("generate: the sum of the initiator is too large for k to fit the edges in the graph")
+	ErrRMATInvalidScale          = errors.New("generate: the scale must be 0 or more")
+	ErrRMATInvalidEdgeFactor     = errors.New("generate: the edge factor must be 0 or more")
+	ErrRMATInvalidProbabilities  = errors.New("generate: the probabilities must be 0 or more and sum up to 1")
+)
+
+const maxKroneckerNodesNum = math.MaxInt32
+
+// Kronecker generates a graph by the stochastic Kronecker model, whose probability matrix
+// is the k-th Kronecker power of the initiator matrix.
+// Instead of materializing the matrix, it drops edges one by one descending the k levels of
+// the initiator until it gets round((sum of the initiator)^k) edges
+// (half of them if undirected), dropping again on loops and duplicates.
+func Kronecker(initiator [][]float64, k int, isDirected bool, rng *rand.Rand) (*graph.Graph, error) {
+	if k < 1 {
+		return nil, ErrKroneckerInvalidK
+	}
+	size := len(initiator)
+	if size == 0 {
+		return nil, ErrKroneckerInvalidInitiator
+	}
+	weights := make([]float64, 0, size*size)
+	for _, row := range initiator {
+		if len(row) != size {
+			return nil, ErrKroneckerInvalidInitiator
+		}
+		for _, p := range row {
+			if !isValidProbability(p) {
+				return nil, ErrKroneckerInvalidInitiator
+			}
+			weights = append(weights, p)
+		}
+	}
+
+	n := 1
+	for i := 0; i < k; i++ {
+		if n > maxKroneckerNodesNum/size {
+			return nil, ErrKroneckerTooLarge
+		}
+		n *= size
+	}
+
+	sum := 0.0
+	for _, p := range weights {
+		sum += p
+	}
+	target := math.Pow(sum, float64(k))
+	if !isDirected {
+		target /= 2
+	}
+	if math.Floor(target+0.5) > float64(maxEdgesNum(n, isDirected)) {
+		return nil, ErrKroneckerTooManyEdges
+	}
+	edgesNum := int(math.Floor(target + 0.5))
+
+	g := newGraph(isDirected)
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	if sum == 0 {
+		return g, nil
+	}
+
+	dropper := newEdgeDropper(weights, size, k)
+
+	cnt := 0
+	for trial := 0; cnt < edgesNum; trial++ {
+		if trial >= 10*edgesNum+100 {
+			return nil, ErrTooManyTrials
+		}
+
+		ok, err := dropper.drop(g, rng)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			cnt++
+		}
+	}
+
+	return g, nil
+}
+
+// RMAT generates a graph of 2^scale nodes by the R-MAT model, dropping
+// edgeFactor * 2^scale edges each of which descends into the quadrants with probabilities a, b, c and d.
+// As in Graph500, loops and duplicates are discarded,
+// so the graph can have fewer edges than dropped.
+func RMAT(scale, edgeFactor int, a, b, c, d float64, isDirected bool, rng *rand.Rand) (*graph.Graph, error) {
+	if scale < 0 {
+		return nil, ErrRMATInvalidScale
+	}
+	if scale > 30 {
+		return nil, ErrKroneckerTooLarge
+	}
+	if edgeFactor < 0 {
+		return nil, ErrRMATInvalidEdgeFactor
+	}
+	if a < 0 || b < 0 || c < 0 || d < 0 || math.Abs(a+b+c+d-1) > 1e-9 {
+		return nil, ErrRMATInvalidProbabilities
+	}
+
+	n := 1 << uint(scale)
+
+	g := newGraph(isDirected)
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	dropper := newEdgeDropper([]float64{a, b, c, d}, 2, scale)
+	for i := int64(0); i < int64(edgeFactor)*int64(n); i++ {
+		if _, err := dropper.drop(g, rng); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+type edgeDropper struct {
+	size   int
+	levels int
+	cums   []float64
+}
+
+// newEdgeDropper creates a dropper of edges into the levels-th Kronecker power of
+// the size x size matrix whose cells are weighted by weights in row-major order.
+func newEdgeDropper(weights []float64, size, levels int) *edgeDropper {
+	cums := make([]float64, len(weights))
+	sum := 0.0
+	for i, w := range weights {
+		sum += w
+		cums[i] = sum
+	}
+	return &edgeDropper{
+		size:   size,
+		levels: levels,
+		cums:   cums,
+	}
+}
+
+// drop drops an edge into the graph, and reports whether the edge has been added,
+// which is false if the edge is a loop or already exists.
+func (dropper *edgeDropper) drop(g *graph.Graph, rng *rand.Rand) (bool, error) {
+	v, w := 0, 0
+	for level := 0; level < dropper.levels; level++ {
+		x := rng.Float64() * dropper.cums[len(dropper.cums)-1]
+		cell := sort.Search(len(dropper.cums), func(i int) bool {
+			return dropper.cums[i] > x
+		})
+		if cell >= len(dropper.cums) {
+			cell = len(dropper.cums) - 1
+		}
+		v = v*dropper.size + cell/dropper.size
+		w = w*dropper.size + cell%dropper.size
+	}
+
+	if v == w {
+		return false, nil
+	}
+	if ok, err := isAdjacent(g, newID(v), newID(w)); err != nil {
+		return false, err
+	} else if ok {
+		return false, nil
+	}
+
+	if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
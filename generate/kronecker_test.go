@@ -0,0 +1,102 @@
+package generate
+
+import "testing"
+
+func TestKronecker(t *testing.T) {
+	initiator := [][]float64{
+		{0.9, 0.5},
+		{0.5, 0.3},
+	}
+
+	for _, isDirected := range []bool{true, false} {
+		g, err := Kronecker(initiator, 8, isDirected, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, 256, g)
+		testUnitWeights(t, g)
+
+		// (0.9 + 0.5 + 0.5 + 0.3)^8 = 548.75...
+		expected := 549
+		if !isDirected {
+			expected = 274
+		}
+		testEdgesNum(t, expected, g)
+	}
+
+	t.Run("success: zero initiator", func(t *testing.T) {
+		g, err := Kronecker([][]float64{{0, 0}, {0, 0}}, 3, true, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, 8, g)
+		testEdgesNum(t, 0, g)
+	})
+
+	t.Run("failure: invalid k", func(t *testing.T) {
+		if _, err := Kronecker(initiator, 0, true, newTestRand()); err != ErrKroneckerInvalidK {
+			t.Errorf("expected: %v, actual: %v", ErrKroneckerInvalidK, err)
+		}
+	})
+
+	t.Run("failure: non-square initiator", func(t *testing.T) {
+		if _, err := Kronecker([][]float64{{0.5, 0.5}}, 2, true, newTestRand()); err != ErrKroneckerInvalidInitiator {
+			t.Errorf("expected: %v, actual: %v", ErrKroneckerInvalidInitiator, err)
+		}
+	})
+
+	t.Run("failure: too many edges", func(t *testing.T) {
+		if _, err := Kronecker([][]float64{{1}}, 3, false, newTestRand()); err != ErrKroneckerTooManyEdges {
+			t.Errorf("expected: %v, actual: %v", ErrKroneckerTooManyEdges, err)
+		}
+	})
+
+	t.Run("failure: too large", func(t *testing.T) {
+		if _, err := Kronecker(initiator, 40, true, newTestRand()); err != ErrKroneckerTooLarge {
+			t.Errorf("expected: %v, actual: %v", ErrKroneckerTooLarge, err)
+		}
+	})
+}
+
+func TestRMAT(t *testing.T) {
+	scale, edgeFactor := 10, 8
+
+	for _, isDirected := range []bool{true, false} {
+		g, err := RMAT(scale, edgeFactor, 0.57, 0.19, 0.19, 0.05, isDirected, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, 1<<uint(scale), g)
+		testUnitWeights(t, g)
+		if cnt := countEdges(g); cnt == 0 || cnt > edgeFactor<<uint(scale) {
+			t.Errorf("expected: (0, %d], actual: %d", edgeFactor<<uint(scale), cnt)
+		}
+
+		// node 0 collects the most edges because a is the largest
+		k0 := degree(t, g, newID(0))
+		for id := range g.GetNodes() {
+			if k := degree(t, g, id); k > k0 {
+				t.Errorf("expected: <= %d, actual: %d", k0, k)
+				break
+			}
+		}
+	}
+
+	t.Run("failure: invalid probabilities", func(t *testing.T) {
+		if _, err := RMAT(scale, edgeFactor, 0.5, 0.2, 0.2, 0.2, true, newTestRand()); err != ErrRMATInvalidProbabilities {
+			t.Errorf("expected: %v, actual: %v", ErrRMATInvalidProbabilities, err)
+		}
+	})
+
+	t.Run("failure: invalid scale", func(t *testing.T) {
+		if _, err := RMAT(-1, edgeFactor, 0.25, 0.25, 0.25, 0.25, true, newTestRand()); err != ErrRMATInvalidScale {
+			t.Errorf("expected: %v, actual: %v", ErrRMATInvalidScale, err)
+		}
+	})
+
+	t.Run("failure: too large scale", func(t *testing.T) {
+		if _, err := RMAT(31, edgeFactor, 0.25, 0.25, 0.25, 0.25, true, newTestRand()); err != ErrKroneckerTooLarge {
+			t.Errorf("expected: %v, actual: %v", ErrKroneckerTooLarge, err)
+		}
+	})
+}
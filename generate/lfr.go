@@ -0,0 +1,421 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrLFRInvalidN           = errors.New("generate: n must be 1 or more")
+	ErrLFRInvalidExponents   = errors.New("generate: the exponents must be 0 or more")
+	ErrLFRInvalidDegrees     = errors.New("generate: the degrees must satisfy 1 <= min degree <= max degree < n")
+	ErrLFRInvalidCommunities = errors.New("generate: the community sizes must satisfy 1 <= min size <= max size <= n")
+	ErrLFRInfeasible         = errors.New("generate: the LFR parameters are infeasible")
+)
+
+const (
+	lfrMaxTrialsNum      = 100
+	lfrRewiringRoundsNum = 10
+	lfrRewiringTrialsNum = 100
+
+	// tolerances of the realized graph to the targets
+	lfrMaxDiscardedRatio = 0.01 // fraction of the stubs left unwired
+	lfrMaxMixingError    = 0.05 // absolute error of the fraction of the external stubs
+)
+
+// LFRConfig is the configuration of the LFR benchmark.
+type LFRConfig struct {
+	N  int
+	Mu float64 // fraction of the edges of each node going out of its community
+
+	Tau1      float64 // exponent of the degree distribution
+	MinDegree int
+	MaxDegree int
+
+	Tau2         float64 // exponent of the community size distribution
+	MinCommunity int
+	MaxCommunity int
+}
+
+// LFR generates an undirected graph by the Lancichinetti–Fortunato–Radicchi benchmark.
+// The degrees and the community sizes follow power laws, and each node has
+// about a fraction Mu of its edges going out of its community.
+// The edges are wired by the erased configuration model,
+// so the degrees can be slightly less than the sampled ones.
+// It fails with ErrLFRInfeasible if more than 1% of the stubs are left unwired
+// or the realized mixing differs from Mu by more than 0.05.
+// It also returns the community of each node.
+func LFR(cfg LFRConfig, rng *rand.Rand) (*graph.Graph, map[graph.ID]int, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	n := cfg.N
+
+	// sample degrees
+	degreeSampler := newPowerLawSampler(cfg.MinDegree, cfg.MaxDegree, cfg.Tau1)
+	degrees := make([]int, n)
+	sum := 0
+	for i := range degrees {
+		degrees[i] = degreeSampler.sample(rng)
+		sum += degrees[i]
+	}
+	if sum%2 != 0 {
+		// make the sum of the degrees even
+		for i := range degrees {
+			if degrees[i] < cfg.MaxDegree {
+				degrees[i]++
+				sum++
+				break
+			} else if degrees[i] > cfg.MinDegree {
+				degrees[i]--
+				sum--
+				break
+			}
+		}
+		if sum%2 != 0 {
+			return nil, nil, fmt.Errorf("%w: the sum of the degrees cannot be even", ErrLFRInfeasible)
+		}
+	}
+
+	// split degrees into internal and external ones
+	indegrees := make([]int, n)
+	maxIndegree := 0
+	hasExternal := false
+	for i, k := range degrees {
+		indegrees[i] = int(math.Floor((1-cfg.Mu)*float64(k) + 0.5))
+		maxIndegree = maxInt(maxIndegree, indegrees[i])
+		hasExternal = hasExternal || indegrees[i] < k
+	}
+	if maxIndegree > cfg.MaxCommunity-1 {
+		return nil, nil, fmt.Errorf(
+			"%w: an internal degree %d does not fit in the max community size %d",
+			ErrLFRInfeasible, maxIndegree, cfg.MaxCommunity,
+		)
+	}
+
+	sizes, err := sampleCommunitySizes(cfg, maxIndegree, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sizes) == 1 && hasExternal {
+		return nil, nil, fmt.Errorf("%w: the external edges need 2 or more communities", ErrLFRInfeasible)
+	}
+
+	members, err := assignCommunities(indegrees, sizes, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	communities := map[graph.ID]int{}
+	communityOf := make([]int, n)
+	for c, vs := range members {
+		for _, v := range vs {
+			communityOf[v] = c
+			communities[newID(v)] = c
+		}
+	}
+
+	// make the sum of the internal degrees in each community even
+	for c, vs := range members {
+		sum := 0
+		for _, v := range vs {
+			sum += indegrees[v]
+		}
+		if sum%2 == 0 {
+			continue
+		}
+		for _, v := range vs {
+			if indegrees[v] < degrees[v] && indegrees[v] < sizes[c]-1 {
+				indegrees[v]++
+				break
+			} else if indegrees[v] > 0 {
+				indegrees[v]--
+				break
+			}
+		}
+	}
+
+	g := graph.NewUndirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, nil, err
+	}
+
+	discarded := 0
+
+	// add internal edges
+	for _, vs := range members {
+		stubs := []int{}
+		for _, v := range vs {
+			for j := 0; j < indegrees[v]; j++ {
+				stubs = append(stubs, v)
+			}
+		}
+		rests, err := wireStubs(g, stubs, nil, rng)
+		if err != nil {
+			return nil, nil, err
+		}
+		discarded += rests
+	}
+
+	// add external edges
+	stubs := []int{}
+	for v := 0; v < n; v++ {
+		for j := 0; j < degrees[v]-indegrees[v]; j++ {
+			stubs = append(stubs, v)
+		}
+	}
+	rests, err := wireStubs(g, stubs, func(v, w int) bool {
+		return communityOf[v] != communityOf[w]
+	}, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	discarded += rests
+
+	// compare the realized graph with the targets
+	if float64(discarded) > lfrMaxDiscardedRatio*float64(sum) {
+		return nil, nil, fmt.Errorf(
+			"%w: %d of the %d stubs cannot be wired",
+			ErrLFRInfeasible, discarded, sum,
+		)
+	}
+	external, total := 0, 0
+	for idTail, heads := range g.GetEdges() {
+		for idHead := range heads {
+			if communities[idTail] != communities[idHead] {
+				external++
+			}
+			total++
+		}
+	}
+	if total > 0 {
+		if mu := float64(external) / float64(total); math.Abs(mu-cfg.Mu) > lfrMaxMixingError {
+			return nil, nil, fmt.Errorf(
+				"%w: the realized mixing %g is too far from %g",
+				ErrLFRInfeasible, mu, cfg.Mu,
+			)
+		}
+	}
+
+	return g, communities, nil
+}
+
+func (cfg LFRConfig) validate() error {
+	if cfg.N < 1 {
+		return ErrLFRInvalidN
+	}
+	if !isValidProbability(cfg.Mu) {
+		return ErrInvalidProbability
+	}
+	if cfg.Tau1 < 0 || cfg.Tau2 < 0 {
+		return ErrLFRInvalidExponents
+	}
+	if cfg.MinDegree < 1 || cfg.MinDegree > cfg.MaxDegree || cfg.MaxDegree >= cfg.N {
+		return ErrLFRInvalidDegrees
+	}
+	if cfg.MinCommunity < 1 || cfg.MinCommunity > cfg.MaxCommunity || cfg.MaxCommunity > cfg.N {
+		return ErrLFRInvalidCommunities
+	}
+	return nil
+}
+
+// sampleCommunitySizes samples community sizes summing up to n,
+// at least one of which is more than maxIndegree.
+func sampleCommunitySizes(cfg LFRConfig, maxIndegree int, rng *rand.Rand) ([]int, error) {
+	sampler := newPowerLawSampler(cfg.MinCommunity, cfg.MaxCommunity, cfg.Tau2)
+
+	for trial := 0; trial < lfrMaxTrialsNum; trial++ {
+		sizes := []int{}
+		sum := 0
+		for sum < cfg.N {
+			size := sampler.sample(rng)
+			sizes = append(sizes, size)
+			sum += size
+		}
+
+		// shrink the communities until the sizes sum up to n
+		for excess, i := sum-cfg.N, 0; excess > 0 && i < len(sizes); i++ {
+			d := minInt(excess, sizes[i]-cfg.MinCommunity)
+			sizes[i] -= d
+			excess -= d
+			sum -= d
+		}
+		if sum != cfg.N {
+			continue
+		}
+
+		for _, size := range sizes {
+			if size-1 >= maxIndegree {
+				return sizes, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: failed to sample community sizes summing up to %d", ErrLFRInfeasible, cfg.N)
+}
+
+// assignCommunities assigns each node v to a community
+// whose size is more than indegrees[v], kicking out a member if it is full.
+func assignCommunities(indegrees, sizes []int, rng *rand.Rand) ([][]int, error) {
+	n := len(indegrees)
+
+	members := make([][]int, len(sizes))
+	for c := range members {
+		members[c] = make([]int, 0, sizes[c])
+	}
+
+	queue := rng.Perm(n)
+	for iteration := 0; len(queue) > 0; iteration++ {
+		if iteration >= 100*n {
+			return nil, fmt.Errorf("%w: failed to assign nodes to communities", ErrLFRInfeasible)
+		}
+
+		v := queue[0]
+		queue = queue[1:]
+
+		c := rng.Intn(len(sizes))
+		if sizes[c]-1 < indegrees[v] {
+			queue = append(queue, v)
+			continue
+		}
+
+		if len(members[c]) < sizes[c] {
+			members[c] = append(members[c], v)
+			continue
+		}
+
+		i := rng.Intn(len(members[c]))
+		queue = append(queue, members[c][i])
+		members[c][i] = v
+	}
+
+	return members, nil
+}
+
+// wireStubs pairs the stubs at random to add edges, discarding the pairs
+// that would form loops or multi-edges or are not accepted.
+// The discarded stubs are paired again for a few rounds, and then each of the remaining pairs
+// (v, w) replaces a random wired edge (x, y) with (v, x) and (w, y) if possible.
+// It returns the number of the stubs left unwired.
+func wireStubs(g *graph.Graph, stubs []int, accepts func(int, int) bool, rng *rand.Rand) (int, error) {
+	canWire := func(v, w int) (bool, error) {
+		if v == w || (accepts != nil && !accepts(v, w)) {
+			return false, nil
+		}
+		ok, err := isAdjacent(g, newID(v), newID(w))
+		return !ok, err
+	}
+
+	wired := [][2]int{}
+	for round := 0; round < lfrRewiringRoundsNum && len(stubs) > 1; round++ {
+		rng.Shuffle(len(stubs), func(i, j int) {
+			stubs[i], stubs[j] = stubs[j], stubs[i]
+		})
+
+		rests := []int{}
+		for i := 0; i+1 < len(stubs); i += 2 {
+			v, w := stubs[i], stubs[i+1]
+			if ok, err := canWire(v, w); err != nil {
+				return 0, err
+			} else if !ok {
+				rests = append(rests, v, w)
+				continue
+			}
+
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return 0, err
+			}
+			wired = append(wired, [2]int{v, w})
+		}
+		if len(stubs)%2 != 0 {
+			rests = append(rests, stubs[len(stubs)-1])
+		}
+		stubs = rests
+	}
+
+	rests := []int{}
+	for i := 0; i+1 < len(stubs); i += 2 {
+		v, w := stubs[i], stubs[i+1]
+
+		isWired := false
+		for trial := 0; trial < lfrRewiringTrialsNum && len(wired) > 0 && !isWired; trial++ {
+			e := rng.Intn(len(wired))
+			x, y := wired[e][0], wired[e][1]
+			if rng.Intn(2) == 0 {
+				x, y = y, x
+			}
+
+			ok1, err := canWire(v, x)
+			if err != nil {
+				return 0, err
+			}
+			ok2, err := canWire(w, y)
+			if err != nil {
+				return 0, err
+			}
+			if !ok1 || !ok2 {
+				continue
+			}
+
+			if err := g.RemoveEdge(newID(x), newID(y)); err != nil {
+				return 0, err
+			}
+			if err := g.AddEdge(newID(v), newID(x), 1.0); err != nil {
+				return 0, err
+			}
+			if err := g.AddEdge(newID(w), newID(y), 1.0); err != nil {
+				return 0, err
+			}
+			wired[e] = [2]int{v, x}
+			wired = append(wired, [2]int{w, y})
+			isWired = true
+		}
+
+		if !isWired {
+			rests = append(rests, v, w)
+		}
+	}
+	if len(stubs)%2 != 0 {
+		rests = append(rests, stubs[len(stubs)-1])
+	}
+
+	return len(rests), nil
+}
+
+type powerLawSampler struct {
+	min  int
+	cums []float64
+}
+
+// newPowerLawSampler creates a sampler of integers k in [min, max]
+// with probability proportional to k^-tau.
+func newPowerLawSampler(min, max int, tau float64) *powerLawSampler {
+	cums := make([]float64, max-min+1)
+	sum := 0.0
+	for k := min; k <= max; k++ {
+		sum += math.Pow(float64(k), -tau)
+		cums[k-min] = sum
+	}
+	return &powerLawSampler{
+		min:  min,
+		cums: cums,
+	}
+}
+
+func (s *powerLawSampler) sample(rng *rand.Rand) int {
+	x := rng.Float64() * s.cums[len(s.cums)-1]
+	return s.min + sort.SearchFloat64s(s.cums, x)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,156 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func newTestLFRConfig() LFRConfig {
+	return LFRConfig{
+		N:            1000,
+		Mu:           0.2,
+		Tau1:         2.5,
+		MinDegree:    5,
+		MaxDegree:    50,
+		Tau2:         1.5,
+		MinCommunity: 20,
+		MaxCommunity: 100,
+	}
+}
+
+func TestLFR(t *testing.T) {
+	cfg := newTestLFRConfig()
+
+	g, communities, err := LFR(cfg, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNodesNum(t, cfg.N, g)
+	testUnitWeights(t, g)
+
+	if len(communities) != cfg.N {
+		t.Errorf("expected: %d, actual: %d", cfg.N, len(communities))
+	}
+	sizes := map[int]int{}
+	for _, c := range communities {
+		sizes[c]++
+	}
+	for _, size := range sizes {
+		if size < cfg.MinCommunity || size > cfg.MaxCommunity {
+			t.Errorf("expected: [%d, %d], actual: %d", cfg.MinCommunity, cfg.MaxCommunity, size)
+		}
+	}
+
+	external, total := 0, 0
+	for idTail, nodeEdges := range g.GetEdges() {
+		for idHead := range nodeEdges {
+			if communities[idTail] != communities[idHead] {
+				external++
+			}
+			total++
+		}
+	}
+	if mu := float64(external) / float64(total); math.Abs(mu-cfg.Mu) > 0.05 {
+		t.Errorf("expected: %f, actual: %f", cfg.Mu, mu)
+	}
+
+	for id := range g.GetNodes() {
+		if k := degree(t, g, id); k > cfg.MaxDegree {
+			t.Errorf("expected: <= %d, actual: %d", cfg.MaxDegree, k)
+		}
+	}
+}
+
+func TestLFR_Failure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(*LFRConfig)
+		err    error
+	}{
+		{
+			"failure: invalid n",
+			func(cfg *LFRConfig) { cfg.N = 0 },
+			ErrLFRInvalidN,
+		},
+		{
+			"failure: invalid mu",
+			func(cfg *LFRConfig) { cfg.Mu = 1.1 },
+			ErrInvalidProbability,
+		},
+		{
+			"failure: invalid exponent",
+			func(cfg *LFRConfig) { cfg.Tau1 = -1 },
+			ErrLFRInvalidExponents,
+		},
+		{
+			"failure: invalid degrees",
+			func(cfg *LFRConfig) { cfg.MinDegree = 60 },
+			ErrLFRInvalidDegrees,
+		},
+		{
+			"failure: invalid communities",
+			func(cfg *LFRConfig) { cfg.MaxCommunity = 2000 },
+			ErrLFRInvalidCommunities,
+		},
+		{
+			"failure: infeasible",
+			func(cfg *LFRConfig) { cfg.MaxCommunity = 30 },
+			ErrLFRInfeasible,
+		},
+		{
+			"failure: external edges in a single community",
+			func(cfg *LFRConfig) {
+				cfg.N, cfg.Mu = 20, 1
+				cfg.MinDegree, cfg.MaxDegree = 3, 5
+				cfg.MinCommunity, cfg.MaxCommunity = 20, 20
+			},
+			ErrLFRInfeasible,
+		},
+		{
+			"failure: odd degree sum",
+			func(cfg *LFRConfig) {
+				cfg.N = 11
+				cfg.MinDegree, cfg.MaxDegree = 3, 3
+				cfg.MinCommunity, cfg.MaxCommunity = 5, 6
+			},
+			ErrLFRInfeasible,
+		},
+		{
+			"failure: unrealizable mixing",
+			func(cfg *LFRConfig) {
+				cfg.Mu = 0.1
+				cfg.MinDegree, cfg.MaxDegree = 2, 2
+			},
+			ErrLFRInfeasible,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestLFRConfig()
+			tc.modify(&cfg)
+
+			if _, _, err := LFR(cfg, newTestRand()); !errors.Is(err, tc.err) {
+				t.Errorf("expected: %v, actual: %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestPowerLawSampler(t *testing.T) {
+	s := newPowerLawSampler(1, 10, 2)
+	rng := newTestRand()
+
+	cnts := map[int]int{}
+	for i := 0; i < 10000; i++ {
+		k := s.sample(rng)
+		if k < 1 || k > 10 {
+			t.Fatalf("expected: [%d, %d], actual: %d", 1, 10, k)
+		}
+		cnts[k]++
+	}
+	if cnts[1] <= cnts[2] || cnts[2] <= cnts[10] {
+		t.Errorf("not decreasing: %v", cnts)
+	}
+}
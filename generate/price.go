@@ -0,0 +1,87 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrPriceInvalidN = errors.New("generate: n must be 0 or more")
+	ErrPriceInvalidM = errors.New("generate: m must be 1 or more")
+	ErrPriceInvalidA = errors.New("generate: a must be more than 0")
+)
+
+// Price generates a directed graph of n nodes by Price's citation model.
+// Each new node cites m distinct older nodes (all of them if fewer), each of which is chosen
+// with probability proportional to its in-degree plus a.
+func Price(n, m int, a float64, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrPriceInvalidN
+	}
+	if m < 1 {
+		return nil, ErrPriceInvalidM
+	}
+	if a <= 0 {
+		return nil, ErrPriceInvalidA
+	}
+
+	g := graph.NewDirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	sampler := newAttachmentSampler(a)
+
+	for v := 1; v < n; v++ {
+		citedNum := minInt(m, v)
+
+		cited := make(map[int]bool, citedNum)
+		targets := make([]int, 0, citedNum)
+		for len(targets) < citedNum {
+			w := sampler.sample(v, rng)
+			if cited[w] {
+				continue
+			}
+			cited[w] = true
+			targets = append(targets, w)
+		}
+
+		for _, w := range targets {
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+			sampler.add(w)
+		}
+	}
+
+	return g, nil
+}
+
+// attachmentSampler samples nodes with probability proportional to their degrees plus delta,
+// where the degrees are counted by add.
+type attachmentSampler struct {
+	ends  []int
+	delta float64
+}
+
+func newAttachmentSampler(delta float64) *attachmentSampler {
+	return &attachmentSampler{
+		ends:  []int{},
+		delta: delta,
+	}
+}
+
+func (s *attachmentSampler) add(v int) {
+	s.ends = append(s.ends, v)
+}
+
+// sample samples one of the nodes 0 to n-1.
+func (s *attachmentSampler) sample(n int, rng *rand.Rand) int {
+	total := float64(len(s.ends)) + s.delta*float64(n)
+	if rng.Float64()*total < float64(len(s.ends)) {
+		return s.ends[rng.Intn(len(s.ends))]
+	}
+	return rng.Intn(n)
+}
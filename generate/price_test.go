@@ -0,0 +1,117 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func maxDegree(t *testing.T, g *graph.Graph, isIn bool) int {
+	kMax := 0
+	for id := range g.GetNodes() {
+		var ends map[graph.ID]*graph.Node
+		var err error
+		if isIn {
+			ends, err = g.GetTails(id)
+		} else {
+			ends, err = g.GetHeads(id)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		kMax = maxInt(kMax, len(ends))
+	}
+	return kMax
+}
+
+func TestPrice(t *testing.T) {
+	type input struct {
+		n, m int
+		a    float64
+	}
+	type output struct {
+		edgesNum int
+		err      error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{
+			"success",
+			input{1000, 3, 1},
+			output{1 + 2 + 997*3, nil},
+		},
+		{
+			"success: n = 1",
+			input{1, 3, 1},
+			output{0, nil},
+		},
+		{
+			"failure: invalid n",
+			input{-1, 3, 1},
+			output{0, ErrPriceInvalidN},
+		},
+		{
+			"failure: invalid m",
+			input{10, 0, 1},
+			output{0, ErrPriceInvalidM},
+		},
+		{
+			"failure: invalid a",
+			input{10, 3, 0},
+			output{0, ErrPriceInvalidA},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := Price(in.n, in.m, in.a, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !g.IsDirected() {
+				t.Errorf("expected: %t, actual: %t", true, g.IsDirected())
+			}
+			testNodesNum(t, in.n, g)
+			testEdgesNum(t, out.edgesNum, g)
+			testUnitWeights(t, g)
+		})
+	}
+}
+
+func TestPrice_HeavyTail(t *testing.T) {
+	g, err := Price(2000, 2, 1, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the in-degrees are heavy-tailed while the out-degrees are at most m
+	if kMax := maxDegree(t, g, true); kMax < 50 {
+		t.Errorf("expected: >= %d, actual: %d", 50, kMax)
+	}
+	if kMax := maxDegree(t, g, false); kMax != 2 {
+		t.Errorf("expected: %d, actual: %d", 2, kMax)
+	}
+}
+
+func TestAttachmentSampler(t *testing.T) {
+	s := newAttachmentSampler(0)
+	s.add(2)
+	for i := 0; i < 10; i++ {
+		if v := s.sample(5, newTestRand()); v != 2 {
+			t.Errorf("expected: %d, actual: %d", 2, v)
+		}
+	}
+
+	s = newAttachmentSampler(0)
+	if v := s.sample(5, newTestRand()); v < 0 || v >= 5 {
+		t.Errorf("expected: [0, 5), actual: %d", v)
+	}
+}
@@ -0,0 +1,144 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrRegularInvalidN = errors.New("generate: n must be 0 or more")
+	ErrRegularInvalidD = errors.New("generate: d must be 0 or more and less than n")
+	ErrRegularOddStubs = errors.New("generate: n * d must be even")
+	ErrKOutInvalidK    = errors.New("generate: k must be 0 or more and less than n")
+)
+
+const regularMaxTrialsNum = 1000
+
+// RandomRegular generates an undirected d-regular graph of n nodes by the algorithm of Steger and Wormald,
+// which pairs the stubs at random, keeps the pairs forming neither loops nor multi-edges,
+// and pairs the rest again, restarting when no suitable pair is left.
+// The graph is asymptotically uniform for d = o(n^(1/28)) and nearly uniform in practice.
+func RandomRegular(n, d int, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrRegularInvalidN
+	}
+	if d < 0 || (n > 0 && d >= n) {
+		return nil, ErrRegularInvalidD
+	}
+	if n*d%2 != 0 {
+		return nil, ErrRegularOddStubs
+	}
+
+	for trial := 0; trial < regularMaxTrialsNum; trial++ {
+		pairs, ok := tryRegularPairs(n, d, rng)
+		if !ok {
+			continue
+		}
+		return newGraphFromPairs(n, pairs)
+	}
+
+	return nil, ErrTooManyTrials
+}
+
+func tryRegularPairs(n, d int, rng *rand.Rand) ([][2]int, bool) {
+	pairs := make([][2]int, 0, n*d/2)
+	isPaired := make(map[[2]int]bool, n*d/2)
+
+	stubs := make([]int, 0, n*d)
+	for v := 0; v < n; v++ {
+		for i := 0; i < d; i++ {
+			stubs = append(stubs, v)
+		}
+	}
+
+	for len(stubs) > 0 {
+		rng.Shuffle(len(stubs), func(i, j int) {
+			stubs[i], stubs[j] = stubs[j], stubs[i]
+		})
+
+		rests := make([]int, n)
+		for i := 0; i < len(stubs); i += 2 {
+			v, w := stubs[i], stubs[i+1]
+			if v > w {
+				v, w = w, v
+			}
+			if v == w || isPaired[[2]int{v, w}] {
+				rests[v]++
+				rests[w]++
+				continue
+			}
+			isPaired[[2]int{v, w}] = true
+			pairs = append(pairs, [2]int{v, w})
+		}
+
+		// collect the rest of the stubs, checking if any of them can still be paired
+		stubs = stubs[:0]
+		candidates := []int{}
+		for v, cnt := range rests {
+			if cnt == 0 {
+				continue
+			}
+			candidates = append(candidates, v)
+			for i := 0; i < cnt; i++ {
+				stubs = append(stubs, v)
+			}
+		}
+		if len(stubs) > 0 && !hasUnpairedPair(candidates, isPaired) {
+			return nil, false
+		}
+	}
+
+	return pairs, true
+}
+
+func hasUnpairedPair(candidates []int, isPaired map[[2]int]bool) bool {
+	for i, v := range candidates {
+		for _, w := range candidates[i+1:] {
+			if !isPaired[[2]int{v, w}] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RandomKOut generates a directed graph of n nodes,
+// in which each node links to k distinct other nodes chosen uniformly.
+func RandomKOut(n, k int, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrRegularInvalidN
+	}
+	if k < 0 || (n > 0 && k >= n) {
+		return nil, ErrKOutInvalidK
+	}
+
+	g := graph.NewDirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	for v := 0; v < n; v++ {
+		// sample k distinct values from [0, n-1) by Floyd's algorithm,
+		// each of which is mapped to a node other than v
+		picked := make(map[int]bool, k)
+		for j := n - 1 - k; j < n-1; j++ {
+			x := rng.Intn(j + 1)
+			if picked[x] {
+				x = j
+			}
+			picked[x] = true
+
+			w := x
+			if w >= v {
+				w++
+			}
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
@@ -0,0 +1,95 @@
+package generate
+
+import "testing"
+
+func TestRandomRegular(t *testing.T) {
+	type input struct {
+		n, d int
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{"success", input{100, 3}, output{nil}},
+		{"success: dense", input{20, 15}, output{nil}},
+		{"success: complete", input{6, 5}, output{nil}},
+		{"success: d = 0", input{5, 0}, output{nil}},
+		{"success: empty", input{0, 0}, output{nil}},
+		{"failure: invalid n", input{-1, 0}, output{ErrRegularInvalidN}},
+		{"failure: d equal to n", input{4, 4}, output{ErrRegularInvalidD}},
+		{"failure: odd stubs", input{5, 3}, output{ErrRegularOddStubs}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := RandomRegular(in.n, in.d, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testNodesNum(t, in.n, g)
+			testEdgesNum(t, in.n*in.d/2, g)
+			testUnitWeights(t, g)
+			for v := 0; v < in.n; v++ {
+				if k := degree(t, g, newID(v)); k != in.d {
+					t.Errorf("expected: %d, actual: %d", in.d, k)
+				}
+			}
+		})
+	}
+}
+
+func TestRandomKOut(t *testing.T) {
+	type input struct {
+		n, k int
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{"success", input{100, 3}, output{nil}},
+		{"success: complete", input{10, 9}, output{nil}},
+		{"success: k = 0", input{10, 0}, output{nil}},
+		{"failure: k equal to n", input{5, 5}, output{ErrKOutInvalidK}},
+		{"failure: negative k", input{5, -1}, output{ErrKOutInvalidK}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := RandomKOut(in.n, in.k, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !g.IsDirected() {
+				t.Errorf("expected: %t, actual: %t", true, g.IsDirected())
+			}
+			testNodesNum(t, in.n, g)
+			testEdgesNum(t, in.n*in.k, g)
+			testUnitWeights(t, g)
+			for v := 0; v < in.n; v++ {
+				if k := degree(t, g, newID(v)); k != in.k {
+					t.Errorf("expected: %d, actual: %d", in.k, k)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrSBMInvalidSizes  = errors.New("generate: the block sizes must be 0 or more")
+	ErrSBMInvalidMatrix = errors.New("generate: the block matrix must be square, non-negative and symmetric if undirected")
+	ErrSBMInvalidThetas = errors.New("generate: the degree parameters must be 0 or more and as many as the nodes")
+)
+
+// SBM generates a graph by the stochastic block model.
+// The nodes are divided into blocks of the given sizes, and each pair of nodes
+// in blocks r and s is connected with probability probs[r][s]
+// (from r to s if directed). It also returns the block of each node.
+func SBM(sizes []int, probs [][]float64, isDirected bool, rng *rand.Rand) (*graph.Graph, map[graph.ID]int, error) {
+	if err := validateBlockSizes(sizes); err != nil {
+		return nil, nil, err
+	}
+	if err := validateBlockMatrix(probs, len(sizes), isDirected); err != nil {
+		return nil, nil, err
+	}
+	for _, row := range probs {
+		for _, p := range row {
+			if !isValidProbability(p) {
+				return nil, nil, ErrInvalidProbability
+			}
+		}
+	}
+
+	g, blocks, offsets, err := newBlockGraph(sizes, isDirected)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for r := range sizes {
+		for s := range sizes {
+			if !isDirected && s < r {
+				continue
+			}
+
+			var total int64
+			var pairFromBlockIndex func(int64) (int, int)
+			if r == s {
+				total = maxEdgesNum(sizes[r], isDirected)
+				pairFromBlockIndex = func(t int64) (int, int) {
+					v, w := pairFromIndex(t, sizes[r], isDirected)
+					return offsets[r] + v, offsets[r] + w
+				}
+			} else {
+				total = int64(sizes[r]) * int64(sizes[s])
+				pairFromBlockIndex = func(t int64) (int, int) {
+					return offsets[r] + int(t/int64(sizes[s])), offsets[s] + int(t%int64(sizes[s]))
+				}
+			}
+
+			if err := sampleIndices(total, probs[r][s], rng, func(t int64) error {
+				v, w := pairFromBlockIndex(t)
+				return g.AddEdge(newID(v), newID(w), 1.0)
+			}); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return g, blocks, nil
+}
+
+// DegreeCorrectedSBM generates a graph by the degree-corrected stochastic block model.
+// Each pair of nodes i and j in blocks r and s is connected with probability
+// 1 - exp(-thetas[i] * thetas[j] * omegas[r][s]), where thetas are normalized to sum to 1 in each block,
+// so that omegas[r][s] approximates the expected number of edges between blocks r and s
+// (twice the number within block r if undirected).
+// It also returns the block of each node.
+func DegreeCorrectedSBM(sizes []int, omegas [][]float64, thetas []float64, isDirected bool, rng *rand.Rand) (*graph.Graph, map[graph.ID]int, error) {
+	if err := validateBlockSizes(sizes); err != nil {
+		return nil, nil, err
+	}
+	if err := validateBlockMatrix(omegas, len(sizes), isDirected); err != nil {
+		return nil, nil, err
+	}
+
+	n := 0
+	for _, size := range sizes {
+		n += size
+	}
+	if len(thetas) != n {
+		return nil, nil, ErrSBMInvalidThetas
+	}
+	for _, theta := range thetas {
+		if theta < 0 {
+			return nil, nil, ErrSBMInvalidThetas
+		}
+	}
+
+	g, blocks, offsets, err := newBlockGraph(sizes, isDirected)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// normalize the degree parameters in each block
+	normalized := make([]float64, n)
+	for r, size := range sizes {
+		sum := 0.0
+		for i := offsets[r]; i < offsets[r]+size; i++ {
+			sum += thetas[i]
+		}
+		if sum == 0 {
+			continue
+		}
+		for i := offsets[r]; i < offsets[r]+size; i++ {
+			normalized[i] = thetas[i] / sum
+		}
+	}
+
+	blockOf := make([]int, n)
+	for r, size := range sizes {
+		for i := offsets[r]; i < offsets[r]+size; i++ {
+			blockOf[i] = r
+		}
+	}
+
+	for v := 0; v < n; v++ {
+		w := 0
+		if !isDirected {
+			w = v + 1
+		}
+		for ; w < n; w++ {
+			if v == w {
+				continue
+			}
+
+			lambda := normalized[v] * normalized[w] * omegas[blockOf[v]][blockOf[w]]
+			if rng.Float64() >= 1-math.Exp(-lambda) {
+				continue
+			}
+
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return g, blocks, nil
+}
+
+func validateBlockSizes(sizes []int) error {
+	for _, size := range sizes {
+		if size < 0 {
+			return ErrSBMInvalidSizes
+		}
+	}
+	return nil
+}
+
+func validateBlockMatrix(matrix [][]float64, blocksNum int, isDirected bool) error {
+	if len(matrix) != blocksNum {
+		return ErrSBMInvalidMatrix
+	}
+	for r, row := range matrix {
+		if len(row) != blocksNum {
+			return ErrSBMInvalidMatrix
+		}
+		for s, x := range row {
+			if x < 0 {
+				return ErrSBMInvalidMatrix
+			}
+			if !isDirected && s < r && x != matrix[s][r] {
+				return ErrSBMInvalidMatrix
+			}
+		}
+	}
+	return nil
+}
+
+// newBlockGraph creates a graph without edges whose nodes are numbered block by block.
+// It also returns the block of each node and the first node number of each block.
+func newBlockGraph(sizes []int, isDirected bool) (*graph.Graph, map[graph.ID]int, []int, error) {
+	g := newGraph(isDirected)
+	blocks := map[graph.ID]int{}
+	offsets := make([]int, len(sizes))
+
+	n := 0
+	for r, size := range sizes {
+		offsets[r] = n
+		for i := 0; i < size; i++ {
+			id := newID(n)
+			if err := g.AddNode(graph.NewNode(id.String())); err != nil {
+				return nil, nil, nil, err
+			}
+			blocks[id] = r
+			n++
+		}
+	}
+
+	return g, blocks, offsets, nil
+}
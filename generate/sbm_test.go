@@ -0,0 +1,150 @@
+package generate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func testBlocks(t *testing.T, sizes []int, blocks map[graph.ID]int) {
+	i := 0
+	for r, size := range sizes {
+		for j := 0; j < size; j++ {
+			if blocks[newID(i)] != r {
+				t.Errorf("expected: %d, actual: %d", r, blocks[newID(i)])
+			}
+			i++
+		}
+	}
+	if len(blocks) != i {
+		t.Errorf("expected: %d, actual: %d", i, len(blocks))
+	}
+}
+
+func countBlockEdges(g *graph.Graph, blocks map[graph.ID]int, r, s int) int {
+	cnt := 0
+	for idTail, nodeEdges := range g.GetEdges() {
+		for idHead := range nodeEdges {
+			if blocks[idTail] == r && blocks[idHead] == s {
+				cnt++
+			}
+		}
+	}
+	return cnt
+}
+
+func TestSBM(t *testing.T) {
+	sizes := []int{100, 200}
+	probs := [][]float64{
+		{0.2, 0.01},
+		{0.01, 0.1},
+	}
+
+	for _, isDirected := range []bool{true, false} {
+		g, blocks, err := SBM(sizes, probs, isDirected, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, 300, g)
+		testUnitWeights(t, g)
+		testBlocks(t, sizes, blocks)
+
+		// the number of edges in each block pair should be within 5 standard deviations
+		for r := range sizes {
+			for s := range sizes {
+				var total float64
+				if r == s {
+					total = float64(sizes[r] * (sizes[r] - 1))
+				} else {
+					total = float64(sizes[r] * sizes[s])
+				}
+				if !isDirected && r == s {
+					total /= 2
+				}
+
+				actual := float64(countBlockEdges(g, blocks, r, s))
+				if !isDirected && r == s {
+					actual /= 2
+				}
+				mean := total * probs[r][s]
+				sd := math.Sqrt(total * probs[r][s] * (1 - probs[r][s]))
+				if math.Abs(actual-mean) > 5*sd {
+					t.Errorf("expected: %f +/- %f, actual: %f", mean, 5*sd, actual)
+				}
+			}
+		}
+	}
+
+	t.Run("success: empty block", func(t *testing.T) {
+		g, _, err := SBM([]int{0, 3}, [][]float64{{1, 1}, {1, 1}}, false, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testEdgesNum(t, 3, g)
+	})
+
+	t.Run("failure: invalid sizes", func(t *testing.T) {
+		if _, _, err := SBM([]int{-1}, [][]float64{{0.1}}, false, newTestRand()); err != ErrSBMInvalidSizes {
+			t.Errorf("expected: %v, actual: %v", ErrSBMInvalidSizes, err)
+		}
+	})
+
+	t.Run("failure: asymmetric matrix", func(t *testing.T) {
+		if _, _, err := SBM([]int{1, 1}, [][]float64{{0, 0.1}, {0.2, 0}}, false, newTestRand()); err != ErrSBMInvalidMatrix {
+			t.Errorf("expected: %v, actual: %v", ErrSBMInvalidMatrix, err)
+		}
+	})
+
+	t.Run("failure: invalid probability", func(t *testing.T) {
+		if _, _, err := SBM([]int{1}, [][]float64{{1.5}}, false, newTestRand()); err != ErrInvalidProbability {
+			t.Errorf("expected: %v, actual: %v", ErrInvalidProbability, err)
+		}
+	})
+}
+
+func TestDegreeCorrectedSBM(t *testing.T) {
+	sizes := []int{200, 200}
+	omegas := [][]float64{
+		{400, 50},
+		{50, 400},
+	}
+	thetas := make([]float64, 400)
+	for i := range thetas {
+		thetas[i] = float64(i%10 + 1)
+	}
+
+	g, blocks, err := DegreeCorrectedSBM(sizes, omegas, thetas, false, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNodesNum(t, 400, g)
+	testUnitWeights(t, g)
+	testBlocks(t, sizes, blocks)
+
+	inner := countBlockEdges(g, blocks, 0, 0) / 2
+	outer := countBlockEdges(g, blocks, 0, 1)
+	if inner <= outer {
+		t.Errorf("expected: > %d, actual: %d", outer, inner)
+	}
+
+	// nodes with larger degree parameters should have larger degrees on average
+	kSmall, kLarge := 0, 0
+	for i := range thetas {
+		switch thetas[i] {
+		case 1:
+			kSmall += degree(t, g, newID(i))
+		case 10:
+			kLarge += degree(t, g, newID(i))
+		}
+	}
+	if kLarge <= kSmall {
+		t.Errorf("expected: > %d, actual: %d", kSmall, kLarge)
+	}
+
+	t.Run("failure: invalid thetas", func(t *testing.T) {
+		if _, _, err := DegreeCorrectedSBM(sizes, omegas, thetas[1:], false, newTestRand()); err != ErrSBMInvalidThetas {
+			t.Errorf("expected: %v, actual: %v", ErrSBMInvalidThetas, err)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrSpatialInvalidN         = errors.New("generate: n must be 0 or more")
+	ErrSpatialInvalidDimension = errors.New("generate: the dimension must be 1 or more")
+	ErrSpatialInvalidRadius    = errors.New("generate: the radius must be 0 or more")
+	ErrWaxmanInvalidAlpha      = errors.New("generate: alpha must be more than 0")
+)
+
+// RandomGeometric generates an undirected graph of n nodes placed uniformly at random
+// in the d-dimensional unit cube (or torus if isTorus), connecting the nodes within distance r.
+// Each node holds its position, which can be retrieved by Position.
+// It runs in near-linear time for small r by dividing the cube into cells of size r or more,
+// and compares all the pairs when there are less than 3 cells per dimension.
+func RandomGeometric(n, d int, r float64, isTorus bool, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrSpatialInvalidN
+	}
+	if d < 1 {
+		return nil, ErrSpatialInvalidDimension
+	}
+	if r < 0 {
+		return nil, ErrSpatialInvalidRadius
+	}
+
+	g := graph.NewUndirected()
+	positions, err := addRandomPositionedNodes(g, n, d, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	// the number of cells per dimension, keeping the number of cells n or less
+	cellsNum := 1
+	if r > 0 {
+		cellsNum = int(math.Min(1/r, math.Pow(float64(n), 1/float64(d))))
+	}
+	if cellsNum < 1 {
+		cellsNum = 1
+	}
+
+	// all the cells are adjacent to each other if there are less than 3 cells per dimension,
+	// so compare all the pairs instead of visiting 3^d offsets
+	if cellsNum < 3 {
+		for v, pos := range positions {
+			for w := v + 1; w < n; w++ {
+				if distance(pos, positions[w], isTorus) > r {
+					continue
+				}
+				if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return g, nil
+	}
+
+	cellOf := func(pos []float64) []int {
+		cell := make([]int, d)
+		for i, x := range pos {
+			cell[i] = minInt(int(x*float64(cellsNum)), cellsNum-1)
+		}
+		return cell
+	}
+	cellIndex := func(cell []int) int {
+		index := 0
+		for _, c := range cell {
+			index = index*cellsNum + c
+		}
+		return index
+	}
+
+	cells := map[int][]int{}
+	for v, pos := range positions {
+		index := cellIndex(cellOf(pos))
+		cells[index] = append(cells[index], v)
+	}
+
+	// offsets to the adjacent cells including the cell itself
+	offsets := [][]int{{}}
+	for i := 0; i < d; i++ {
+		next := [][]int{}
+		for _, offset := range offsets {
+			for _, delta := range []int{-1, 0, 1} {
+				next = append(next, append(append([]int{}, offset...), delta))
+			}
+		}
+		offsets = next
+	}
+
+	for v, pos := range positions {
+		cell := cellOf(pos)
+
+		visited := map[int]bool{}
+		adjacent := make([]int, d)
+		for _, offset := range offsets {
+			isInside := true
+			for i := range adjacent {
+				c := cell[i] + offset[i]
+				if isTorus {
+					c = (c + cellsNum) % cellsNum
+				} else if c < 0 || c >= cellsNum {
+					isInside = false
+					break
+				}
+				adjacent[i] = c
+			}
+			if !isInside {
+				continue
+			}
+
+			index := cellIndex(adjacent)
+			if visited[index] {
+				continue
+			}
+			visited[index] = true
+
+			for _, w := range cells[index] {
+				if w <= v || distance(pos, positions[w], isTorus) > r {
+					continue
+				}
+				if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// Waxman generates an undirected graph of n nodes placed uniformly at random in the unit square
+// by the Waxman model, in which each pair of nodes at distance x is connected
+// with probability beta * exp(-x / (alpha * L)), where L is the diameter of the square.
+// Each node holds its position, which can be retrieved by Position.
+func Waxman(n int, alpha, beta float64, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrSpatialInvalidN
+	}
+	if alpha <= 0 {
+		return nil, ErrWaxmanInvalidAlpha
+	}
+	if !isValidProbability(beta) {
+		return nil, ErrInvalidProbability
+	}
+
+	g := graph.NewUndirected()
+	positions, err := addRandomPositionedNodes(g, n, 2, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	l := math.Sqrt2
+	for v := 0; v < n; v++ {
+		for w := v + 1; w < n; w++ {
+			p := beta * math.Exp(-distance(positions[v], positions[w], false)/(alpha*l))
+			if rng.Float64() >= p {
+				continue
+			}
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
+
+func addRandomPositionedNodes(g *graph.Graph, n, d int, rng *rand.Rand) ([][]float64, error) {
+	positions := make([][]float64, n)
+	for v := range positions {
+		pos := make([]float64, d)
+		for i := range pos {
+			pos[i] = rng.Float64()
+		}
+		positions[v] = pos
+
+		node := graph.NewNode(newID(v).String())
+		node.SetPosition(pos)
+		if err := g.AddNode(node); err != nil {
+			return nil, err
+		}
+	}
+	return positions, nil
+}
+
+// distance calculates the Euclidean distance between the positions in the unit cube,
+// or the unit torus if isTorus.
+func distance(pos1, pos2 []float64, isTorus bool) float64 {
+	sum := 0.0
+	for i := range pos1 {
+		diff := math.Abs(pos1[i] - pos2[i])
+		if isTorus {
+			diff = math.Min(diff, 1-diff)
+		}
+		sum += diff * diff
+	}
+	return math.Sqrt(sum)
+}
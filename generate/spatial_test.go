@@ -0,0 +1,125 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func testPositions(t *testing.T, g *graph.Graph, d int) {
+	for _, n := range g.GetNodes() {
+		pos := n.Position()
+		if len(pos) != d {
+			t.Errorf("expected: %d, actual: %d", d, len(pos))
+			continue
+		}
+		for _, x := range pos {
+			if x < 0 || x >= 1 {
+				t.Errorf("expected: [0, 1), actual: %f", x)
+			}
+		}
+	}
+}
+
+func TestRandomGeometric(t *testing.T) {
+	type input struct {
+		n, d    int
+		r       float64
+		isTorus bool
+	}
+	testCases := []struct {
+		name string
+		in   input
+	}{
+		{"success: 2d", input{500, 2, 0.08, false}},
+		{"success: 2d torus", input{500, 2, 0.08, true}},
+		{"success: 1d torus", input{100, 1, 0.02, true}},
+		{"success: 3d", input{300, 3, 0.2, false}},
+		{"success: large radius torus", input{50, 2, 0.6, true}},
+		{"success: zero radius", input{50, 2, 0, false}},
+		{"success: high dimension", input{200, 12, 0.8, false}},
+		{"success: high dimension torus", input{200, 12, 0.8, true}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in := tc.in
+
+			g, err := RandomGeometric(in.n, in.d, in.r, in.isTorus, newTestRand())
+			if err != nil {
+				t.Fatal(err)
+			}
+			testNodesNum(t, in.n, g)
+			testUnitWeights(t, g)
+			testPositions(t, g, in.d)
+
+			// compare with the brute force
+			nodes := g.GetNodes()
+			expected := 0
+			for v := 0; v < in.n; v++ {
+				for w := v + 1; w < in.n; w++ {
+					pos1, pos2 := nodes[newID(v)].Position(), nodes[newID(w)].Position()
+					if distance(pos1, pos2, in.isTorus) <= in.r {
+						expected++
+					}
+				}
+			}
+			testEdgesNum(t, expected, g)
+		})
+	}
+
+	t.Run("failure: invalid dimension", func(t *testing.T) {
+		if _, err := RandomGeometric(10, 0, 0.1, false, newTestRand()); err != ErrSpatialInvalidDimension {
+			t.Errorf("expected: %v, actual: %v", ErrSpatialInvalidDimension, err)
+		}
+	})
+
+	t.Run("failure: invalid radius", func(t *testing.T) {
+		if _, err := RandomGeometric(10, 2, -0.1, false, newTestRand()); err != ErrSpatialInvalidRadius {
+			t.Errorf("expected: %v, actual: %v", ErrSpatialInvalidRadius, err)
+		}
+	})
+}
+
+func TestWaxman(t *testing.T) {
+	g, err := Waxman(200, 0.1, 0.5, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNodesNum(t, 200, g)
+	testUnitWeights(t, g)
+	testPositions(t, g, 2)
+
+	// edges should be shorter than random pairs on average
+	sum := 0.0
+	for _, nodeEdges := range g.GetEdges() {
+		for _, e := range nodeEdges {
+			sum += distance(e.Tail().Position(), e.Head().Position(), false)
+		}
+	}
+	if avg := sum / float64(2*countEdges(g)); avg >= 0.5 {
+		t.Errorf("expected: < %f, actual: %f", 0.5, avg)
+	}
+
+	t.Run("failure: invalid alpha", func(t *testing.T) {
+		if _, err := Waxman(10, 0, 0.5, newTestRand()); err != ErrWaxmanInvalidAlpha {
+			t.Errorf("expected: %v, actual: %v", ErrWaxmanInvalidAlpha, err)
+		}
+	})
+
+	t.Run("failure: invalid beta", func(t *testing.T) {
+		if _, err := Waxman(10, 0.1, 2, newTestRand()); err != ErrInvalidProbability {
+			t.Errorf("expected: %v, actual: %v", ErrInvalidProbability, err)
+		}
+	})
+}
+
+func TestDistance(t *testing.T) {
+	pos1, pos2 := []float64{0.1, 0.5}, []float64{0.9, 0.5}
+	if d := distance(pos1, pos2, false); d < 0.8-1e-9 || d > 0.8+1e-9 {
+		t.Errorf("expected: %f, actual: %f", 0.8, d)
+	}
+	if d := distance(pos1, pos2, true); d < 0.2-1e-9 || d > 0.2+1e-9 {
+		t.Errorf("expected: %f, actual: %f", 0.2, d)
+	}
+}
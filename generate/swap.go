@@ -0,0 +1,189 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrInvalidSwapsNum = errors.New("generate: the number of swaps must be 0 or more and the maximum number of trials or less")
+	ErrTooFewEdges     = errors.New("generate: the graph must have 2 or more edges")
+)
+
+// DoubleEdgeSwap randomizes the graph in place keeping the degree of every node,
+// by swapping the ends of two random edges swapsNum times.
+// A swap is rejected if it would create a loop or a multi-edge.
+// It returns the number of the performed swaps, which is less than swapsNum
+// only when it gives up after maxTrialsNum trials.
+func DoubleEdgeSwap(g *graph.Graph, swapsNum, maxTrialsNum int, rng *rand.Rand) (int, error) {
+	return doubleEdgeSwap(g, swapsNum, maxTrialsNum, false, rng)
+}
+
+// ConnectedDoubleEdgeSwap is DoubleEdgeSwap that also rejects the swaps
+// splitting a (weakly) connected component of the graph.
+func ConnectedDoubleEdgeSwap(g *graph.Graph, swapsNum, maxTrialsNum int, rng *rand.Rand) (int, error) {
+	return doubleEdgeSwap(g, swapsNum, maxTrialsNum, true, rng)
+}
+
+type swapEdge struct {
+	idTail graph.ID
+	idHead graph.ID
+	weight float64
+}
+
+func doubleEdgeSwap(g *graph.Graph, swapsNum, maxTrialsNum int, keepsConnected bool, rng *rand.Rand) (int, error) {
+	if swapsNum < 0 || maxTrialsNum < swapsNum {
+		return 0, ErrInvalidSwapsNum
+	}
+
+	edges := newSwapEdges(g)
+	if len(edges) < 2 {
+		if swapsNum == 0 {
+			return 0, nil
+		}
+		return 0, ErrTooFewEdges
+	}
+
+	swapped := 0
+	for trial := 0; trial < maxTrialsNum && swapped < swapsNum; trial++ {
+		i, j := rng.Intn(len(edges)), rng.Intn(len(edges)-1)
+		if j >= i {
+			j++
+		}
+		e1, e2 := edges[i], edges[j]
+		if !g.IsDirected() && rng.Intn(2) == 0 {
+			e2.idTail, e2.idHead = e2.idHead, e2.idTail
+		}
+
+		// (a, b), (c, d) -> (a, d), (c, b)
+		eNew1 := swapEdge{e1.idTail, e2.idHead, e1.weight}
+		eNew2 := swapEdge{e2.idTail, e1.idHead, e2.weight}
+		if eNew1.idTail == eNew1.idHead || eNew2.idTail == eNew2.idHead {
+			continue
+		}
+		if ok, err := isAdjacent(g, eNew1.idTail, eNew1.idHead); err != nil {
+			return swapped, err
+		} else if ok {
+			continue
+		}
+		if ok, err := isAdjacent(g, eNew2.idTail, eNew2.idHead); err != nil {
+			return swapped, err
+		} else if ok {
+			continue
+		}
+
+		if err := replaceEdges(g, []swapEdge{e1, e2}, []swapEdge{eNew1, eNew2}); err != nil {
+			return swapped, err
+		}
+
+		if keepsConnected {
+			ok1, err := isReachable(g, e1.idTail, e1.idHead)
+			if err != nil {
+				return swapped, err
+			}
+			ok2, err := isReachable(g, e2.idTail, e2.idHead)
+			if err != nil {
+				return swapped, err
+			}
+			if !ok1 || !ok2 {
+				// revert the swap
+				if err := replaceEdges(g, []swapEdge{eNew1, eNew2}, []swapEdge{e1, e2}); err != nil {
+					return swapped, err
+				}
+				continue
+			}
+		}
+
+		edges[i], edges[j] = eNew1, eNew2
+		swapped++
+	}
+
+	if swapped < swapsNum {
+		return swapped, ErrTooManyTrials
+	}
+
+	return swapped, nil
+}
+
+// newSwapEdges lists the edges of the graph in a deterministic order,
+// listing each undirected edge only once.
+func newSwapEdges(g *graph.Graph) []swapEdge {
+	edges := []swapEdge{}
+	for idTail, nodeEdges := range g.GetEdges() {
+		for idHead, e := range nodeEdges {
+			if !g.IsDirected() && idTail > idHead {
+				continue
+			}
+			edges = append(edges, swapEdge{idTail, idHead, e.Weight()})
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].idTail != edges[j].idTail {
+			return edges[i].idTail < edges[j].idTail
+		}
+		return edges[i].idHead < edges[j].idHead
+	})
+
+	return edges
+}
+
+func replaceEdges(g *graph.Graph, edgesOld, edgesNew []swapEdge) error {
+	for _, e := range edgesOld {
+		if err := g.RemoveEdge(e.idTail, e.idHead); err != nil {
+			return err
+		}
+	}
+	for _, e := range edgesNew {
+		if err := g.AddEdge(e.idTail, e.idHead, e.weight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isAdjacent(g *graph.Graph, idTail, idHead graph.ID) (bool, error) {
+	heads, err := g.GetHeads(idTail)
+	if err != nil {
+		return false, err
+	}
+	_, ok := heads[idHead]
+	return ok, nil
+}
+
+// isReachable reports whether there is a path between the two nodes,
+// ignoring the directions of the edges.
+func isReachable(g *graph.Graph, idFrom, idTo graph.ID) (bool, error) {
+	visited := map[graph.ID]bool{idFrom: true}
+	queue := []graph.ID{idFrom}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == idTo {
+			return true, nil
+		}
+
+		heads, err := g.GetHeads(id)
+		if err != nil {
+			return false, err
+		}
+		tails, err := g.GetTails(id)
+		if err != nil {
+			return false, err
+		}
+		for _, ends := range []map[graph.ID]*graph.Node{heads, tails} {
+			for idNext := range ends {
+				if !visited[idNext] {
+					visited[idNext] = true
+					queue = append(queue, idNext)
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
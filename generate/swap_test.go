@@ -0,0 +1,103 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func testDegreesPreserved(t *testing.T, expected, actual *graph.Graph) {
+	for id := range expected.GetNodes() {
+		for _, f := range []func(*graph.Graph, graph.ID) (map[graph.ID]*graph.Node, error){
+			(*graph.Graph).GetHeads,
+			(*graph.Graph).GetTails,
+		} {
+			endsExpected, err := f(expected, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			endsActual, err := f(actual, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(endsActual) != len(endsExpected) {
+				t.Errorf("expected: %d, actual: %d", len(endsExpected), len(endsActual))
+			}
+		}
+	}
+}
+
+func TestDoubleEdgeSwap(t *testing.T) {
+	for _, isDirected := range []bool{true, false} {
+		gOrig, err := GNM(50, 150, isDirected, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		g := gOrig.Copy()
+
+		swapped, err := DoubleEdgeSwap(g, 100, 10000, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if swapped != 100 {
+			t.Errorf("expected: %d, actual: %d", 100, swapped)
+		}
+		testEdgesNum(t, 150, g)
+		testUnitWeights(t, g)
+		testDegreesPreserved(t, gOrig, g)
+	}
+
+	t.Run("failure: too few edges", func(t *testing.T) {
+		g, err := GNM(5, 1, false, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := DoubleEdgeSwap(g, 1, 10, newTestRand()); err != ErrTooFewEdges {
+			t.Errorf("expected: %v, actual: %v", ErrTooFewEdges, err)
+		}
+	})
+
+	t.Run("failure: invalid swaps num", func(t *testing.T) {
+		g := graph.NewUndirected()
+		if _, err := DoubleEdgeSwap(g, 10, 5, newTestRand()); err != ErrInvalidSwapsNum {
+			t.Errorf("expected: %v, actual: %v", ErrInvalidSwapsNum, err)
+		}
+	})
+
+	t.Run("failure: too many trials", func(t *testing.T) {
+		// no swap is possible in a complete graph
+		g, err := GNP(5, 1, false, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		swapped, err := DoubleEdgeSwap(g, 1, 100, newTestRand())
+		if err != ErrTooManyTrials {
+			t.Errorf("expected: %v, actual: %v", ErrTooManyTrials, err)
+		}
+		if swapped != 0 {
+			t.Errorf("expected: %d, actual: %d", 0, swapped)
+		}
+	})
+}
+
+func TestConnectedDoubleEdgeSwap(t *testing.T) {
+	gOrig, err := WattsStrogatz(50, 4, 0.1, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := gOrig.Copy()
+
+	if _, err := ConnectedDoubleEdgeSwap(g, 200, 100000, newTestRand()); err != nil {
+		t.Fatal(err)
+	}
+	testDegreesPreserved(t, gOrig, g)
+	for id := range g.GetNodes() {
+		ok, err := isReachable(g, newID(0), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Errorf("expected: %t, actual: %t", true, ok)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package generate
+
+import (
+	"errors"
+	"math"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrTopologyInvalidN          = errors.New("generate: the number of nodes must be 0 or more")
+	ErrTopologyInvalidDimensions = errors.New("generate: the dimensions must be 1 or more")
+	ErrTopologyInvalidBranches   = errors.New("generate: the number of branches must be 1 or more")
+	ErrTopologyInvalidHeight     = errors.New("generate: the height must be 0 or more")
+	ErrTopologyTooLarge          = errors.New("generate: the number of nodes is too large")
+)
+
+const maxTopologyNodesNum = math.MaxInt32
+
+// Path generates an undirected path of nodes 0 to n-1 in order.
+func Path(n int) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrTopologyInvalidN
+	}
+
+	pairs := [][2]int{}
+	for i := 0; i < n-1; i++ {
+		pairs = append(pairs, [2]int{i, i + 1})
+	}
+
+	return newGraphFromPairs(n, pairs)
+}
+
+// Cycle generates an undirected cycle of nodes 0 to n-1 in order,
+// which is a path if n is 2 or less.
+func Cycle(n int) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrTopologyInvalidN
+	}
+
+	return newGraphFromPairs(n, ringPairs(n))
+}
+
+// Star generates an undirected star of the center 0 and the leaves 1 to n.
+func Star(n int) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrTopologyInvalidN
+	}
+
+	pairs := [][2]int{}
+	for i := 1; i <= n; i++ {
+		pairs = append(pairs, [2]int{0, i})
+	}
+
+	return newGraphFromPairs(n+1, pairs)
+}
+
+// Wheel generates an undirected wheel of n nodes,
+// the hub 0 connected to all the nodes of the cycle of nodes 1 to n-1.
+func Wheel(n int) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrTopologyInvalidN
+	}
+
+	pairs := [][2]int{}
+	for i := 1; i < n; i++ {
+		pairs = append(pairs, [2]int{0, i})
+	}
+	for _, pair := range ringPairs(n - 1) {
+		pairs = append(pairs, [2]int{pair[0] + 1, pair[1] + 1})
+	}
+
+	return newGraphFromPairs(n, pairs)
+}
+
+// Complete generates an undirected complete graph of nodes 0 to n-1.
+func Complete(n int) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrTopologyInvalidN
+	}
+
+	return newGraphFromPairs(n, completePairs(0, n))
+}
+
+// CompleteBipartite generates an undirected complete bipartite graph
+// between nodes 0 to n1-1 and nodes n1 to n1+n2-1.
+func CompleteBipartite(n1, n2 int) (*graph.Graph, error) {
+	if n1 < 0 || n2 < 0 {
+		return nil, ErrTopologyInvalidN
+	}
+
+	pairs := [][2]int{}
+	for i := 0; i < n1; i++ {
+		for j := n1; j < n1+n2; j++ {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+
+	return newGraphFromPairs(n1+n2, pairs)
+}
+
+// Grid generates an undirected grid graph of the given dimensions.
+// The node at the coordinates (x_0, x_1, ..., x_{d-1}) is numbered in row-major order,
+// that is x_{d-1} + dims[d-1] * (x_{d-2} + dims[d-2] * (...)), and holds the coordinates as its position.
+func Grid(dims []int) (*graph.Graph, error) {
+	return newLattice(dims, false)
+}
+
+// Torus generates an undirected torus graph of the given dimensions,
+// which is a grid whose boundaries are connected periodically.
+// The nodes are numbered and positioned as Grid.
+func Torus(dims []int) (*graph.Graph, error) {
+	return newLattice(dims, true)
+}
+
+// Hypercube generates an undirected d-dimensional hypercube graph of 2^d nodes,
+// in which the nodes whose numbers differ in exactly one bit are connected.
+func Hypercube(d int) (*graph.Graph, error) {
+	if d < 0 {
+		return nil, ErrTopologyInvalidDimensions
+	}
+	if d > 30 {
+		return nil, ErrTopologyTooLarge
+	}
+
+	n := 1 << uint(d)
+	pairs := [][2]int{}
+	for v := 0; v < n; v++ {
+		for i := 0; i < d; i++ {
+			if w := v ^ (1 << uint(i)); v < w {
+				pairs = append(pairs, [2]int{v, w})
+			}
+		}
+	}
+
+	return newGraphFromPairs(n, pairs)
+}
+
+// Tree generates an undirected balanced r-ary tree of height h numbered in breadth-first order,
+// in which the root is 0 and the children of node i are r*i+1 to r*i+r.
+func Tree(r, h int) (*graph.Graph, error) {
+	if r < 1 {
+		return nil, ErrTopologyInvalidBranches
+	}
+	if h < 0 {
+		return nil, ErrTopologyInvalidHeight
+	}
+
+	n, width := 0, 1
+	for level := 0; ; level++ {
+		if width > maxTopologyNodesNum-n {
+			return nil, ErrTopologyTooLarge
+		}
+		n += width
+		if level == h {
+			break
+		}
+		if width > maxTopologyNodesNum/r {
+			return nil, ErrTopologyTooLarge
+		}
+		width *= r
+	}
+
+	pairs := [][2]int{}
+	for v := 1; v < n; v++ {
+		pairs = append(pairs, [2]int{(v - 1) / r, v})
+	}
+
+	return newGraphFromPairs(n, pairs)
+}
+
+// Barbell generates an undirected barbell graph of two complete graphs of m1 nodes
+// connected by a path of m2 nodes, numbered 0 to m1-1, m1 to m1+m2-1 and m1+m2 to 2*m1+m2-1.
+func Barbell(m1, m2 int) (*graph.Graph, error) {
+	if m1 < 1 || m2 < 0 {
+		return nil, ErrTopologyInvalidN
+	}
+
+	n := 2*m1 + m2
+	pairs := completePairs(0, m1)
+	for v := m1 - 1; v < m1+m2; v++ {
+		pairs = append(pairs, [2]int{v, v + 1})
+	}
+	pairs = append(pairs, completePairs(m1+m2, n)...)
+
+	return newGraphFromPairs(n, pairs)
+}
+
+// Lollipop generates an undirected lollipop graph of a complete graph of nodes 0 to m-1
+// and a path of nodes m to m+n-1 connected to node m-1.
+func Lollipop(m, n int) (*graph.Graph, error) {
+	if m < 1 || n < 0 {
+		return nil, ErrTopologyInvalidN
+	}
+
+	pairs := completePairs(0, m)
+	for v := m - 1; v < m+n-1; v++ {
+		pairs = append(pairs, [2]int{v, v + 1})
+	}
+
+	return newGraphFromPairs(m+n, pairs)
+}
+
+// Petersen generates the undirected Petersen graph of the outer cycle of nodes 0 to 4,
+// the inner pentagram of nodes 5 to 9 and the spokes between nodes i and i+5.
+func Petersen() (*graph.Graph, error) {
+	pairs := [][2]int{}
+	for i := 0; i < 5; i++ {
+		pairs = append(pairs,
+			[2]int{i, (i + 1) % 5},
+			[2]int{i, i + 5},
+			[2]int{i + 5, (i+2)%5 + 5},
+		)
+	}
+
+	return newGraphFromPairs(10, pairs)
+}
+
+func newGraphFromPairs(n int, pairs [][2]int) (*graph.Graph, error) {
+	g := graph.NewUndirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	for _, pair := range pairs {
+		if err := g.AddEdge(newID(pair[0]), newID(pair[1]), 1.0); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// completePairs lists all the pairs of nodes from to to-1.
+func completePairs(from, to int) [][2]int {
+	pairs := [][2]int{}
+	for v := from; v < to; v++ {
+		for w := v + 1; w < to; w++ {
+			pairs = append(pairs, [2]int{v, w})
+		}
+	}
+	return pairs
+}
+
+func newLattice(dims []int, isPeriodic bool) (*graph.Graph, error) {
+	if len(dims) == 0 {
+		return nil, ErrTopologyInvalidDimensions
+	}
+	n := 1
+	for _, size := range dims {
+		if size < 0 {
+			return nil, ErrTopologyInvalidN
+		}
+		if size > 0 && n > maxTopologyNodesNum/size {
+			return nil, ErrTopologyTooLarge
+		}
+		n *= size
+	}
+
+	g := graph.NewUndirected()
+
+	// strides[i] is the difference of the numbers between the adjacent nodes along the i-th dimension
+	strides := make([]int, len(dims))
+	stride := 1
+	for i := len(dims) - 1; i >= 0; i-- {
+		strides[i] = stride
+		stride *= dims[i]
+	}
+
+	coords := make([][]int, n)
+	for v := 0; v < n; v++ {
+		coord := make([]int, len(dims))
+		pos := make([]float64, len(dims))
+		for i := range dims {
+			coord[i] = v / strides[i] % dims[i]
+			pos[i] = float64(coord[i])
+		}
+		coords[v] = coord
+
+		node := graph.NewNode(newID(v).String())
+		node.SetPosition(pos)
+		if err := g.AddNode(node); err != nil {
+			return nil, err
+		}
+	}
+
+	for v := 0; v < n; v++ {
+		for i, size := range dims {
+			var w int
+			if coords[v][i]+1 < size {
+				w = v + strides[i]
+			} else if isPeriodic && size > 2 {
+				// a periodic boundary of size 2 or less would duplicate the edge
+				w = v - (size-1)*strides[i]
+			} else {
+				continue
+			}
+
+			if err := g.AddEdge(newID(v), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
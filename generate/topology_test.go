@@ -0,0 +1,117 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func TestTopologies(t *testing.T) {
+	type output struct {
+		nodesNum int
+		edgesNum int
+		err      error
+	}
+	testCases := []struct {
+		name     string
+		generate func() (*graph.Graph, error)
+		out      output
+	}{
+		{"path", func() (*graph.Graph, error) { return Path(5) }, output{5, 4, nil}},
+		{"path: empty", func() (*graph.Graph, error) { return Path(0) }, output{0, 0, nil}},
+		{"cycle", func() (*graph.Graph, error) { return Cycle(5) }, output{5, 5, nil}},
+		{"cycle: 2 nodes", func() (*graph.Graph, error) { return Cycle(2) }, output{2, 1, nil}},
+		{"star", func() (*graph.Graph, error) { return Star(5) }, output{6, 5, nil}},
+		{"wheel", func() (*graph.Graph, error) { return Wheel(6) }, output{6, 10, nil}},
+		{"complete", func() (*graph.Graph, error) { return Complete(6) }, output{6, 15, nil}},
+		{"complete bipartite", func() (*graph.Graph, error) { return CompleteBipartite(3, 4) }, output{7, 12, nil}},
+		{"grid", func() (*graph.Graph, error) { return Grid([]int{3, 4}) }, output{12, 17, nil}},
+		{"grid: 3d", func() (*graph.Graph, error) { return Grid([]int{2, 2, 2}) }, output{8, 12, nil}},
+		{"torus", func() (*graph.Graph, error) { return Torus([]int{3, 4}) }, output{12, 24, nil}},
+		{"torus: size 2", func() (*graph.Graph, error) { return Torus([]int{2, 3}) }, output{6, 9, nil}},
+		{"hypercube", func() (*graph.Graph, error) { return Hypercube(4) }, output{16, 32, nil}},
+		{"hypercube: 0d", func() (*graph.Graph, error) { return Hypercube(0) }, output{1, 0, nil}},
+		{"tree", func() (*graph.Graph, error) { return Tree(3, 2) }, output{13, 12, nil}},
+		{"tree: unary", func() (*graph.Graph, error) { return Tree(1, 3) }, output{4, 3, nil}},
+		{"barbell", func() (*graph.Graph, error) { return Barbell(4, 2) }, output{10, 15, nil}},
+		{"barbell: no path", func() (*graph.Graph, error) { return Barbell(3, 0) }, output{6, 7, nil}},
+		{"lollipop", func() (*graph.Graph, error) { return Lollipop(4, 3) }, output{7, 9, nil}},
+		{"petersen", Petersen, output{10, 15, nil}},
+		{"failure: negative n", func() (*graph.Graph, error) { return Complete(-1) }, output{0, 0, ErrTopologyInvalidN}},
+		{"failure: no dimensions", func() (*graph.Graph, error) { return Grid(nil) }, output{0, 0, ErrTopologyInvalidDimensions}},
+		{"failure: invalid branches", func() (*graph.Graph, error) { return Tree(0, 2) }, output{0, 0, ErrTopologyInvalidBranches}},
+		{"failure: invalid height", func() (*graph.Graph, error) { return Tree(2, -1) }, output{0, 0, ErrTopologyInvalidHeight}},
+		{"failure: too large", func() (*graph.Graph, error) { return Hypercube(40) }, output{0, 0, ErrTopologyTooLarge}},
+		{"failure: too large hypercube", func() (*graph.Graph, error) { return Hypercube(31) }, output{0, 0, ErrTopologyTooLarge}},
+		{"failure: too large tree", func() (*graph.Graph, error) { return Tree(2, 31) }, output{0, 0, ErrTopologyTooLarge}},
+		{"failure: too large grid", func() (*graph.Graph, error) { return Grid([]int{1 << 16, 1 << 16}) }, output{0, 0, ErrTopologyTooLarge}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := tc.out
+
+			g, err := tc.generate()
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testNodesNum(t, out.nodesNum, g)
+			testEdgesNum(t, out.edgesNum, g)
+			testUnitWeights(t, g)
+		})
+	}
+}
+
+func TestPetersen(t *testing.T) {
+	g, err := Petersen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if k := degree(t, g, newID(i)); k != 3 {
+			t.Errorf("expected: %d, actual: %d", 3, k)
+		}
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g, err := Grid([]int{3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// node 6 is at (1, 2)
+	n, err := g.GetNode(newID(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos := n.Position(); len(pos) != 2 || pos[0] != 1 || pos[1] != 2 {
+		t.Errorf("expected: %v, actual: %v", []float64{1, 2}, pos)
+	}
+	for _, id := range []graph.ID{"2", "5", "7", "10"} {
+		if ok, _ := isAdjacent(g, newID(6), id); !ok {
+			t.Errorf("expected: %t, actual: %t", true, ok)
+		}
+	}
+}
+
+func TestTree(t *testing.T) {
+	g, err := Tree(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []graph.ID{"1", "2"} {
+		if ok, _ := isAdjacent(g, "0", id); !ok {
+			t.Errorf("expected: %t, actual: %t", true, ok)
+		}
+	}
+	for _, id := range []graph.ID{"11", "12"} {
+		if ok, _ := isAdjacent(g, "5", id); !ok {
+			t.Errorf("expected: %t, actual: %t", true, ok)
+		}
+	}
+}
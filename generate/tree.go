@@ -0,0 +1,259 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrTreeInvalidN         = errors.New("generate: n must be 0 or more")
+	ErrNotTree              = errors.New("generate: the graph is not a tree")
+	ErrTreeInvalidIDs       = errors.New("generate: the nodes of the tree must be numbered 0 to n-1")
+	ErrTreeTooFewNodes      = errors.New("generate: the tree must have 2 or more nodes")
+	ErrInvalidPruferElement = errors.New("generate: the elements of the Prüfer sequence must be between 0 and its length + 1")
+)
+
+// IsTree reports whether the graph is an undirected tree, connected and acyclic with 1 or more nodes.
+func IsTree(g *graph.Graph) bool {
+	if g.IsDirected() {
+		return false
+	}
+
+	nodes := g.GetNodes()
+	if len(nodes) == 0 {
+		return false
+	}
+
+	edgesNum := 0
+	for _, heads := range g.GetEdges() {
+		edgesNum += len(heads)
+	}
+	if edgesNum/2 != len(nodes)-1 {
+		return false
+	}
+
+	// a graph of n nodes and n-1 edges is a tree if it is connected
+	var idFrom graph.ID
+	for id := range nodes {
+		idFrom = id
+		break
+	}
+	visited := map[graph.ID]bool{idFrom: true}
+	stack := []graph.ID{idFrom}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for idHead := range g.GetEdges()[id] {
+			if visited[idHead] {
+				continue
+			}
+			visited[idHead] = true
+			stack = append(stack, idHead)
+		}
+	}
+
+	return len(visited) == len(nodes)
+}
+
+// PruferEncode returns the Prüfer sequence of the tree of nodes 0 to n-1,
+// removing the leaf of the smallest number n-2 times and recording its neighbor.
+func PruferEncode(g *graph.Graph) ([]int, error) {
+	if !IsTree(g) {
+		return nil, ErrNotTree
+	}
+
+	n := len(g.GetNodes())
+	if n < 2 {
+		return nil, ErrTreeTooFewNodes
+	}
+
+	neighbors := make([][]int, n)
+	for id := range g.GetNodes() {
+		v, err := strconv.Atoi(id.String())
+		if err != nil || v < 0 || v >= n || newID(v) != id {
+			return nil, ErrTreeInvalidIDs
+		}
+		heads, err := g.GetHeads(id)
+		if err != nil {
+			return nil, err
+		}
+		for idHead := range heads {
+			w, err := strconv.Atoi(idHead.String())
+			if err != nil {
+				return nil, ErrTreeInvalidIDs
+			}
+			neighbors[v] = append(neighbors[v], w)
+		}
+	}
+
+	degrees := make([]int, n)
+	for v := range neighbors {
+		degrees[v] = len(neighbors[v])
+	}
+	removed := make([]bool, n)
+
+	seq := make([]int, 0, n-2)
+	ptr := 0
+	for ptr < n && degrees[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+	for len(seq) < n-2 {
+		removed[leaf] = true
+		parent := -1
+		for _, w := range neighbors[leaf] {
+			if !removed[w] {
+				parent = w
+				break
+			}
+		}
+		seq = append(seq, parent)
+
+		degrees[parent]--
+		if degrees[parent] == 1 && parent < ptr {
+			// the parent becomes the smallest leaf
+			leaf = parent
+			continue
+		}
+		ptr++
+		for ptr < n && degrees[ptr] != 1 {
+			ptr++
+		}
+		leaf = ptr
+	}
+
+	return seq, nil
+}
+
+// PruferDecode generates the undirected tree of nodes 0 to n-1 whose Prüfer sequence is seq,
+// where n is the length of seq + 2.
+func PruferDecode(seq []int) (*graph.Graph, error) {
+	n := len(seq) + 2
+	for _, v := range seq {
+		if v < 0 || v >= n {
+			return nil, ErrInvalidPruferElement
+		}
+	}
+
+	degrees := make([]int, n)
+	for v := range degrees {
+		degrees[v] = 1
+	}
+	for _, v := range seq {
+		degrees[v]++
+	}
+
+	pairs := make([][2]int, 0, n-1)
+	ptr := 0
+	for degrees[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+	for _, v := range seq {
+		pairs = append(pairs, [2]int{leaf, v})
+
+		degrees[v]--
+		if degrees[v] == 1 && v < ptr {
+			// v becomes the smallest leaf
+			leaf = v
+			continue
+		}
+		ptr++
+		for degrees[ptr] != 1 {
+			ptr++
+		}
+		leaf = ptr
+	}
+	pairs = append(pairs, [2]int{leaf, n - 1})
+
+	return newGraphFromPairs(n, pairs)
+}
+
+// RandomTree generates a uniform random labeled tree of nodes 0 to n-1
+// by decoding a uniform random Prüfer sequence.
+func RandomTree(n int, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrTreeInvalidN
+	}
+	if n < 2 {
+		return newGraphFromPairs(n, nil)
+	}
+
+	return PruferDecode(randomPruferSequence(n, rng))
+}
+
+// RandomRecursiveTree generates a random recursive tree of nodes 0 to n-1,
+// in which each node i > 0 is connected to a node chosen uniformly from 0 to i-1.
+func RandomRecursiveTree(n int, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrTreeInvalidN
+	}
+
+	pairs := make([][2]int, 0, n)
+	for v := 1; v < n; v++ {
+		pairs = append(pairs, [2]int{rng.Intn(v), v})
+	}
+
+	return newGraphFromPairs(n, pairs)
+}
+
+// RandomForest generates a uniform random rooted forest of nodes 0 to n-1
+// with the root of the tree to which each node belongs.
+// It decodes a uniform random tree of n+1 nodes and removes node n,
+// whose neighbors become the roots.
+func RandomForest(n int, rng *rand.Rand) (*graph.Graph, map[graph.ID]graph.ID, error) {
+	if n < 0 {
+		return nil, nil, ErrTreeInvalidN
+	}
+	if n == 0 {
+		return graph.NewUndirected(), map[graph.ID]graph.ID{}, nil
+	}
+
+	g, err := PruferDecode(randomPruferSequence(n+1, rng))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idVirtual := newID(n)
+	heads, err := g.GetHeads(idVirtual)
+	if err != nil {
+		return nil, nil, err
+	}
+	rootIDs := make([]graph.ID, 0, len(heads))
+	for id := range heads {
+		rootIDs = append(rootIDs, id)
+	}
+	if err := g.RemoveNode(idVirtual); err != nil {
+		return nil, nil, err
+	}
+
+	roots := make(map[graph.ID]graph.ID, n)
+	for _, idRoot := range rootIDs {
+		roots[idRoot] = idRoot
+		stack := []graph.ID{idRoot}
+		for len(stack) > 0 {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for idHead := range g.GetEdges()[id] {
+				if _, ok := roots[idHead]; ok {
+					continue
+				}
+				roots[idHead] = idRoot
+				stack = append(stack, idHead)
+			}
+		}
+	}
+
+	return g, roots, nil
+}
+
+func randomPruferSequence(n int, rng *rand.Rand) []int {
+	seq := make([]int, n-2)
+	for i := range seq {
+		seq[i] = rng.Intn(n)
+	}
+	return seq
+}
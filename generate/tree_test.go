@@ -0,0 +1,285 @@
+package generate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func TestIsTree(t *testing.T) {
+	path, err := Path(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := Path(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cycle, err := Cycle(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forest, err := newGraphFromPairs(5, [][2]int{{0, 1}, {1, 2}, {3, 4}, {2, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	directed := graph.NewDirected()
+	for _, id := range []string{"0", "1"} {
+		if err := directed.AddNode(graph.NewNode(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := directed.AddEdge("0", "1", 1.0); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		g        *graph.Graph
+		expected bool
+	}{
+		{"path", path, true},
+		{"single", single, true},
+		{"empty", graph.NewUndirected(), false},
+		{"cycle", cycle, false},
+		{"disconnected with n-1 edges", forest, false},
+		{"directed", directed, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := IsTree(tc.g); actual != tc.expected {
+				t.Errorf("expected: %v, actual: %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPruferDecode(t *testing.T) {
+	type output struct {
+		pairs [][2]int
+		err   error
+	}
+	testCases := []struct {
+		name string
+		seq  []int
+		out  output
+	}{
+		{"success", []int{3, 3, 3, 4}, output{[][2]int{{0, 3}, {1, 3}, {2, 3}, {3, 4}, {4, 5}}, nil}},
+		{"success: path", []int{1, 2}, output{[][2]int{{0, 1}, {1, 2}, {2, 3}}, nil}},
+		{"success: 2 nodes", []int{}, output{[][2]int{{0, 1}}, nil}},
+		{"failure: too large element", []int{0, 4}, output{nil, ErrInvalidPruferElement}},
+		{"failure: negative element", []int{-1}, output{nil, ErrInvalidPruferElement}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := PruferDecode(tc.seq)
+			if err != tc.out.err {
+				t.Errorf("expected: %v, actual: %v", tc.out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testNodesNum(t, len(tc.seq)+2, g)
+			testEdgesNum(t, len(tc.out.pairs), g)
+			for _, pair := range tc.out.pairs {
+				if ok, err := isAdjacent(g, newID(pair[0]), newID(pair[1])); err != nil || !ok {
+					t.Errorf("expected: %v, actual: absent", pair)
+				}
+			}
+		})
+	}
+}
+
+func TestPruferEncode(t *testing.T) {
+	// the encoding is the inverse of the decoding for all the sequences of 5 nodes
+	n := 5
+	trees := map[string]bool{}
+	for code := 0; code < n*n*n; code++ {
+		seq := []int{code % n, code / n % n, code / n / n}
+
+		g, err := PruferDecode(seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !IsTree(g) {
+			t.Errorf("expected: tree, actual: %v", g.GetEdges())
+		}
+
+		actual, err := PruferEncode(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(seq, actual) {
+			t.Errorf("expected: %v, actual: %v", seq, actual)
+		}
+		key := ""
+		for v := 0; v < n; v++ {
+			for w := v + 1; w < n; w++ {
+				if ok, err := isAdjacent(g, newID(v), newID(w)); err != nil {
+					t.Fatal(err)
+				} else if ok {
+					key += fmt.Sprint(v, w, " ")
+				}
+			}
+		}
+		trees[key] = true
+	}
+	if len(trees) != n*n*n {
+		t.Errorf("expected: %d, actual: %d", n*n*n, len(trees))
+	}
+
+	cycle, err := Cycle(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := Path(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	named := graph.NewUndirected()
+	for _, id := range []string{"0", "x"} {
+		if err := named.AddNode(graph.NewNode(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := named.AddEdge("0", "x", 1.0); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		g        *graph.Graph
+		expected error
+	}{
+		{"failure: not tree", cycle, ErrNotTree},
+		{"failure: too few nodes", single, ErrTreeTooFewNodes},
+		{"failure: invalid ids", named, ErrTreeInvalidIDs},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := PruferEncode(tc.g); err != tc.expected {
+				t.Errorf("expected: %v, actual: %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRandomTree(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 100} {
+		g, err := RandomTree(n, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, n, g)
+		if n > 0 && !IsTree(g) {
+			t.Errorf("expected: tree, actual: %v", g.GetEdges())
+		}
+	}
+
+	// each of the 16 trees of 4 nodes appears uniformly
+	rng := newTestRand()
+	trialsNum := 16000
+	counts := map[string]int{}
+	for i := 0; i < trialsNum; i++ {
+		g, err := RandomTree(4, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seq, err := PruferEncode(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[fmt.Sprint(seq)]++
+	}
+	if len(counts) != 16 {
+		t.Errorf("expected: %d, actual: %d", 16, len(counts))
+	}
+	for seq, count := range counts {
+		if count < 850 || count > 1150 {
+			t.Errorf("%s: expected: about %d, actual: %d", seq, trialsNum/16, count)
+		}
+	}
+
+	if _, err := RandomTree(-1, newTestRand()); err != ErrTreeInvalidN {
+		t.Errorf("expected: %v, actual: %v", ErrTreeInvalidN, err)
+	}
+}
+
+func TestRandomRecursiveTree(t *testing.T) {
+	for _, n := range []int{1, 2, 10, 100} {
+		g, err := RandomRecursiveTree(n, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, n, g)
+		testUnitWeights(t, g)
+		if !IsTree(g) {
+			t.Errorf("expected: tree, actual: %v", g.GetEdges())
+		}
+	}
+
+	if _, err := RandomRecursiveTree(-1, newTestRand()); err != ErrTreeInvalidN {
+		t.Errorf("expected: %v, actual: %v", ErrTreeInvalidN, err)
+	}
+}
+
+func TestRandomForest(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 100} {
+		g, roots, err := RandomForest(n, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNodesNum(t, n, g)
+		if len(roots) != n {
+			t.Errorf("expected: %d, actual: %d", n, len(roots))
+		}
+
+		// each tree has exactly one root
+		rootsNum := 0
+		for id, idRoot := range roots {
+			if id == idRoot {
+				rootsNum++
+			}
+			if roots[idRoot] != idRoot {
+				t.Errorf("expected: %s, actual: %s", idRoot, roots[idRoot])
+			}
+		}
+		testEdgesNum(t, n-rootsNum, g)
+		for idTail, heads := range g.GetEdges() {
+			for idHead := range heads {
+				if roots[idTail] != roots[idHead] {
+					t.Errorf("expected: %s, actual: %s", roots[idTail], roots[idHead])
+				}
+			}
+		}
+	}
+
+	// each of the 3 rooted forests of 2 nodes appears uniformly
+	rng := newTestRand()
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		_, roots, err := RandomForest(2, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[fmt.Sprint(roots["0"], roots["1"])]++
+	}
+	if len(counts) != 3 {
+		t.Errorf("expected: %d, actual: %d", 3, len(counts))
+	}
+	for forest, count := range counts {
+		if count < 900 || count > 1100 {
+			t.Errorf("%s: expected: about %d, actual: %d", forest, 1000, count)
+		}
+	}
+
+	if _, _, err := RandomForest(-1, newTestRand()); err != ErrTreeInvalidN {
+		t.Errorf("expected: %v, actual: %v", ErrTreeInvalidN, err)
+	}
+}
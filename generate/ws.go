@@ -0,0 +1,154 @@
+package generate
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+var (
+	ErrWSInvalidN = errors.New("generate: n must be 1 or more")
+	ErrWSInvalidK = errors.New("generate: k must be a non-negative even number less than n")
+)
+
+// WattsStrogatz generates an undirected graph of n nodes by the WS model,
+// rewiring each edge of a ring lattice of degree k with probability p.
+func WattsStrogatz(n, k int, p float64, rng *rand.Rand) (*graph.Graph, error) {
+	g, err := newRingLattice(n, k, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// rewire edges
+	for j := 1; j <= k/2; j++ {
+		for i := 0; i < n; i++ {
+			if rng.Float64() >= p {
+				continue
+			}
+
+			w, ok, err := pickNonNeighbor(g, n, i, rng)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				// the node is saturated
+				continue
+			}
+
+			if err := g.RemoveEdge(newID(i), newID((i+j)%n)); err != nil {
+				return nil, err
+			}
+			if err := g.AddEdge(newID(i), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// NewmanWatts generates an undirected graph of n nodes by the NW model,
+// adding a shortcut for each edge of a ring lattice of degree k with probability p.
+func NewmanWatts(n, k int, p float64, rng *rand.Rand) (*graph.Graph, error) {
+	g, err := newRingLattice(n, k, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// add shortcuts
+	for j := 1; j <= k/2; j++ {
+		for i := 0; i < n; i++ {
+			if rng.Float64() >= p {
+				continue
+			}
+
+			w, ok, err := pickNonNeighbor(g, n, i, rng)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				// the node is saturated
+				continue
+			}
+
+			if err := g.AddEdge(newID(i), newID(w), 1.0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
+
+func newRingLattice(n, k int, p float64) (*graph.Graph, error) {
+	if n < 1 {
+		return nil, ErrWSInvalidN
+	}
+	if k < 0 || k%2 != 0 || k >= n {
+		return nil, ErrWSInvalidK
+	}
+	if !isValidProbability(p) {
+		return nil, ErrInvalidProbability
+	}
+
+	g := graph.NewUndirected()
+	if err := addNodes(g, n); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 1; j <= k/2; j++ {
+			if err := g.AddEdge(newID(i), newID((i+j)%n), 1.0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// pickNonNeighbor picks a node of the undirected graph of nodes 0 to n-1
+// uniformly from the nodes that are neither i nor adjacent to i.
+// ok is false if there is no such node.
+func pickNonNeighbor(g *graph.Graph, n, i int, rng *rand.Rand) (w int, ok bool, err error) {
+	heads, err := g.GetHeads(newID(i))
+	if err != nil {
+		return 0, false, err
+	}
+
+	candidatesNum := n - 1 - len(heads)
+	if candidatesNum <= 0 {
+		return 0, false, nil
+	}
+
+	// rejection sampling is fast enough while at least half of the nodes are candidates
+	if 2*candidatesNum >= n {
+		for {
+			w = rng.Intn(n)
+			if w == i {
+				continue
+			}
+			if _, ok := heads[newID(w)]; ok {
+				continue
+			}
+			return w, true, nil
+		}
+	}
+
+	target := rng.Intn(candidatesNum)
+	for w = 0; w < n; w++ {
+		if w == i {
+			continue
+		}
+		if _, ok := heads[newID(w)]; ok {
+			continue
+		}
+		if target == 0 {
+			return w, true, nil
+		}
+		target--
+	}
+
+	return 0, false, nil
+}
@@ -0,0 +1,158 @@
+package generate
+
+import "testing"
+
+func TestWattsStrogatz(t *testing.T) {
+	type input struct {
+		n, k int
+		p    float64
+	}
+	type output struct {
+		err error
+	}
+	testCases := []struct {
+		name string
+		in   input
+		out  output
+	}{
+		{
+			"success",
+			input{100, 4, 0.1},
+			output{nil},
+		},
+		{
+			"success: p = 0",
+			input{100, 4, 0},
+			output{nil},
+		},
+		{
+			"success: p = 1",
+			input{100, 4, 1},
+			output{nil},
+		},
+		{
+			"success: saturated",
+			input{5, 4, 1},
+			output{nil},
+		},
+		{
+			"success: k = 0",
+			input{10, 0, 0.5},
+			output{nil},
+		},
+		{
+			"failure: invalid n",
+			input{0, 0, 0.5},
+			output{ErrWSInvalidN},
+		},
+		{
+			"failure: odd k",
+			input{10, 3, 0.5},
+			output{ErrWSInvalidK},
+		},
+		{
+			"failure: k equal to n",
+			input{4, 4, 0.5},
+			output{ErrWSInvalidK},
+		},
+		{
+			"failure: invalid p",
+			input{10, 2, 1.5},
+			output{ErrInvalidProbability},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in, out := tc.in, tc.out
+
+			g, err := WattsStrogatz(in.n, in.k, in.p, newTestRand())
+			if err != out.err {
+				t.Errorf("expected: %v, actual: %v", out.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			testNodesNum(t, in.n, g)
+			testEdgesNum(t, in.n*in.k/2, g)
+			testUnitWeights(t, g)
+		})
+	}
+}
+
+func TestWattsStrogatz_Lattice(t *testing.T) {
+	n, k := 20, 4
+
+	g, err := WattsStrogatz(n, k, 0, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		for j := 1; j <= k/2; j++ {
+			if _, err := g.GetEdge(newID(i), newID((i+j)%n)); err != nil {
+				t.Errorf("expected: %v, actual: %v", nil, err)
+			}
+		}
+	}
+}
+
+func TestNewmanWatts(t *testing.T) {
+	n, k := 100, 4
+
+	g, err := NewmanWatts(n, k, 0.2, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNodesNum(t, n, g)
+	testUnitWeights(t, g)
+	if countEdges(g) <= n*k/2 {
+		t.Errorf("expected: > %d, actual: %d", n*k/2, countEdges(g))
+	}
+	for i := 0; i < n; i++ {
+		for j := 1; j <= k/2; j++ {
+			if _, err := g.GetEdge(newID(i), newID((i+j)%n)); err != nil {
+				t.Errorf("expected: %v, actual: %v", nil, err)
+			}
+		}
+	}
+
+	t.Run("success: saturated", func(t *testing.T) {
+		g, err := NewmanWatts(5, 4, 1, newTestRand())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testEdgesNum(t, 10, g)
+	})
+
+	t.Run("failure: odd k", func(t *testing.T) {
+		if _, err := NewmanWatts(10, 3, 0.5, newTestRand()); err != ErrWSInvalidK {
+			t.Errorf("expected: %v, actual: %v", ErrWSInvalidK, err)
+		}
+	})
+}
+
+func TestPickNonNeighbor(t *testing.T) {
+	g, err := newRingLattice(6, 4, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, ok, err := pickNonNeighbor(g, 6, 0, newTestRand())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("expected: %t, actual: %t", true, ok)
+	}
+	if w != 3 {
+		t.Errorf("expected: %d, actual: %d", 3, w)
+	}
+
+	if err := g.AddEdge(newID(0), newID(3), 1.0); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := pickNonNeighbor(g, 6, 0, newTestRand()); ok {
+		t.Errorf("expected: %t, actual: %t", false, ok)
+	}
+}
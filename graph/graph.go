@@ -42,6 +42,25 @@ func (g *Graph) IsDirected() bool {
 	return g.isDirected
 }
 
+func (g *Graph) Copy() *Graph {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	gCopy := newGraph(g.isDirected)
+
+	for id, n := range g.nodes {
+		gCopy.nodes[id] = n.Copy()
+	}
+	for idTail, nodeEdges := range g.edges {
+		for idHead, e := range nodeEdges {
+			gCopy.addEdge(idTail, idHead, e.Weight())
+			gCopy.addRelation(idTail, idHead)
+		}
+	}
+
+	return gCopy
+}
+
 func (g *Graph) isExistNode(id ID) (exists bool) {
 	_, exists = g.nodes[id]
 	return
@@ -142,6 +142,43 @@ func TestGraph_IsDirected(t *testing.T) {
 	})
 }
 
+func TestGraph_Copy(t *testing.T) {
+	for _, isDirected := range []bool{true, false} {
+		g := newGraph(isDirected)
+		for _, id := range []string{"1", "2", "3"} {
+			g.AddNode(NewNode(id))
+		}
+		g.AddEdge("1", "2", 1.0)
+		g.AddEdge("2", "3", 2.0)
+
+		g.nodes["1"].SetPosition([]float64{0.1, 0.2})
+
+		gCopy := g.Copy()
+		testGraphEquality(t, g, gCopy)
+
+		gCopy.nodes["1"].SetPosition([]float64{0.3, 0.4})
+		if pos := g.nodes["1"].Position(); pos[0] != 0.1 || pos[1] != 0.2 {
+			t.Errorf("expected: %v, actual: %v", []float64{0.1, 0.2}, pos)
+		}
+
+		gCopy.RemoveEdge("1", "2")
+		gCopy.AddEdge("2", "3", 1.0)
+
+		e, err := g.GetEdge("1", "2")
+		if err != nil {
+			t.Errorf("expected: %v, actual: %v", nil, err)
+		} else if e.Weight() != 1.0 {
+			t.Errorf("expected: %f, actual: %f", 1.0, e.Weight())
+		}
+		e, err = g.GetEdge("2", "3")
+		if err != nil {
+			t.Errorf("expected: %v, actual: %v", nil, err)
+		} else if e.Weight() != 2.0 {
+			t.Errorf("expected: %f, actual: %f", 2.0, e.Weight())
+		}
+	}
+}
+
 func TestGraph_GetNode(t *testing.T) {
 	type input struct {
 		id ID
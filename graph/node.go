@@ -1,7 +1,8 @@
 package graph
 
 type Node struct {
-	id ID
+	id       ID
+	position []float64
 }
 
 func NewNode(id string) *Node {
@@ -13,3 +14,28 @@ func NewNode(id string) *Node {
 func (n *Node) ID() ID {
 	return n.id
 }
+
+// Copy returns a node with the same ID and a copy of the position.
+func (n *Node) Copy() *Node {
+	return &Node{
+		id:       n.id,
+		position: copyPosition(n.position),
+	}
+}
+
+// Position returns a copy of the position, or nil if not set.
+func (n *Node) Position() []float64 {
+	return copyPosition(n.position)
+}
+
+// SetPosition sets a copy of the position.
+func (n *Node) SetPosition(position []float64) {
+	n.position = copyPosition(position)
+}
+
+func copyPosition(position []float64) []float64 {
+	if position == nil {
+		return nil
+	}
+	return append([]float64{}, position...)
+}
@@ -22,3 +22,44 @@ func TestNewNode(t *testing.T) {
 		t.Errorf("expected: %q, actual: %q", id, n.ID())
 	}
 }
+
+func TestSetPosition(t *testing.T) {
+	n := &Node{}
+	if n.Position() != nil {
+		t.Errorf("expected: nil, actual: %v", n.Position())
+	}
+
+	n.SetPosition([]float64{0.1, 0.2})
+	if len(n.Position()) != 2 {
+		t.Errorf("expected: %d, actual: %d", 2, len(n.Position()))
+		return
+	}
+	if n.Position()[0] != 0.1 || n.Position()[1] != 0.2 {
+		t.Errorf("expected: %v, actual: %v", []float64{0.1, 0.2}, n.Position())
+	}
+
+	// the position is not aliased with the given or returned slices
+	position := []float64{0.3, 0.4}
+	n.SetPosition(position)
+	position[0] = 0.5
+	n.Position()[1] = 0.6
+	if n.Position()[0] != 0.3 || n.Position()[1] != 0.4 {
+		t.Errorf("expected: %v, actual: %v", []float64{0.3, 0.4}, n.Position())
+	}
+}
+
+func TestNode_Copy(t *testing.T) {
+	n := NewNode("1")
+	n.SetPosition([]float64{0.1, 0.2})
+
+	nCopy := n.Copy()
+	testNodeEquality(t, n, nCopy)
+	if nCopy == n {
+		t.Errorf("expected: a different node, actual: the same node")
+	}
+
+	nCopy.SetPosition([]float64{0.3})
+	if len(n.Position()) != 2 || n.Position()[0] != 0.1 {
+		t.Errorf("expected: %v, actual: %v", []float64{0.1, 0.2}, n.Position())
+	}
+}
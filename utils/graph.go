@@ -1,10 +1,16 @@
 package utils
 
 import (
+	"fmt"
+
 	"github.com/awalterschulze/gographviz"
 	"github.com/m0t0k1ch1/nebula/graph"
 )
 
+// DOTPositionScale is the number of inches per unit of the node positions in DOT graphs,
+// as Graphviz reads pos in inches and the generated positions are usually in the unit square.
+var DOTPositionScale = 10.0
+
 var (
 	defaultGraphAttrs = map[string]string{
 		string(gographviz.Layout): "fdp",
@@ -38,7 +44,7 @@ func NewDOTGraph(g *graph.Graph) (*gographviz.Graph, error) {
 	// add nodes
 	nodes := g.GetNodes()
 	for _, n := range nodes {
-		if err := gv.AddNode(gv.Name, n.ID().String(), defaultNodeAttrs); err != nil {
+		if err := gv.AddNode(gv.Name, n.ID().String(), newNodeAttrs(n)); err != nil {
 			return nil, err
 		}
 	}
@@ -68,3 +74,22 @@ func NewDOTGraph(g *graph.Graph) (*gographviz.Graph, error) {
 
 	return gv, nil
 }
+
+func newNodeAttrs(n *graph.Node) map[string]string {
+	pos := n.Position()
+	if len(pos) < 2 {
+		return defaultNodeAttrs
+	}
+
+	attrs := make(map[string]string, len(defaultNodeAttrs)+1)
+	for k, v := range defaultNodeAttrs {
+		attrs[k] = v
+	}
+	// pin the node at the first 2 coordinates of its position
+	attrs[string(gographviz.Pos)] = fmt.Sprintf(
+		"\"%g,%g!\"",
+		pos[0]*DOTPositionScale, pos[1]*DOTPositionScale,
+	)
+
+	return attrs
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/awalterschulze/gographviz"
+	"github.com/m0t0k1ch1/nebula/graph"
+)
+
+func TestNewNodeAttrs(t *testing.T) {
+	type output struct {
+		pos   string
+		isPos bool
+	}
+	testCases := []struct {
+		name     string
+		position []float64
+		out      output
+	}{
+		{"no position", nil, output{"", false}},
+		{"1d position", []float64{0.5}, output{"", false}},
+		{"2d position", []float64{0.1, 0.25}, output{"\"1,2.5!\"", true}},
+		{"3d position", []float64{0.5, 0, 0.3}, output{"\"5,0!\"", true}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := graph.NewNode("1")
+			n.SetPosition(tc.position)
+
+			attrs := newNodeAttrs(n)
+			pos, ok := attrs[string(gographviz.Pos)]
+			if ok != tc.out.isPos {
+				t.Errorf("expected: %t, actual: %t", tc.out.isPos, ok)
+			}
+			if pos != tc.out.pos {
+				t.Errorf("expected: %s, actual: %s", tc.out.pos, pos)
+			}
+			for k, v := range defaultNodeAttrs {
+				if attrs[k] != v {
+					t.Errorf("expected: %s, actual: %s", v, attrs[k])
+				}
+			}
+		})
+	}
+
+	// the default attributes are left as they are
+	if _, ok := defaultNodeAttrs[string(gographviz.Pos)]; ok {
+		t.Errorf("expected: %t, actual: %t", false, ok)
+	}
+}